@@ -0,0 +1,133 @@
+package calc_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// backendVector is a test case expressed as strings so it can be fed to
+// every backend.
+type backendVector struct {
+	name     string
+	op       string
+	a, b     string
+	expected string
+	err      error
+}
+
+// sharedVectors must give identical results on the int and big backends.
+var sharedVectors = []backendVector{
+	{"sum", "sum", "2", "3", "5", nil},
+	{"sum negatives", "sum", "-1", "-2", "-3", nil},
+	{"subtract", "subtract", "3", "5", "-2", nil},
+	{"multiply", "multiply", "-3", "4", "-12", nil},
+	{"multiply by zero", "multiply", "12345", "0", "0", nil},
+	{"divide", "divide", "20", "3", "6", nil},
+	{"divide truncates negative", "divide", "-7", "2", "-3", nil},
+	{"divide by zero", "divide", "5", "0", "", calc.ErrDivisionByZero},
+	{"int64 max", "sum", "9223372036854775806", "1", "9223372036854775807", nil},
+}
+
+// bigOnlyVectors exceed int64 and only run against the big backend.
+var bigOnlyVectors = []backendVector{
+	{"sum past int64", "sum", "9223372036854775807", "1", "9223372036854775808", nil},
+	{"multiply past int64", "multiply", "9223372036854775807", "9223372036854775807",
+		"85070591730234615847396907784232501249", nil},
+	{"divide huge", "divide", "85070591730234615847396907784232501249", "9223372036854775807",
+		"9223372036854775807", nil},
+	{"subtract below int64", "subtract", "-9223372036854775808", "1", "-9223372036854775809", nil},
+}
+
+// runVectors applies each vector through the given backend.
+func runVectors[T any](t *testing.T, ops calc.Operations[T], vectors []backendVector) {
+	t.Helper()
+
+	apply := map[string]func(a, b T) (T, error){
+		"sum":      ops.Sum,
+		"subtract": ops.Subtract,
+		"multiply": ops.Multiply,
+		"divide":   ops.Divide,
+	}
+
+	for _, tt := range vectors {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ops.Parse(tt.a)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.a, err)
+			}
+			b, err := ops.Parse(tt.b)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.b, err)
+			}
+
+			result, err := apply[tt.op](a, b)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("%s(%s, %s) error = %v; want %v", tt.op, tt.a, tt.b, err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s(%s, %s) unexpected error: %v", tt.op, tt.a, tt.b, err)
+			}
+			if got := ops.Format(result); got != tt.expected {
+				t.Errorf("%s(%s, %s) = %s; want %s", tt.op, tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestBackends_SharedVectors runs the same table against both precisions.
+func TestBackends_SharedVectors(t *testing.T) {
+	t.Run("int", func(t *testing.T) { runVectors(t, calc.Int, sharedVectors) })
+	t.Run("big", func(t *testing.T) { runVectors(t, calc.Big, sharedVectors) })
+}
+
+// TestBigBackend_BeyondInt64 demonstrates values the int backend cannot hold.
+func TestBigBackend_BeyondInt64(t *testing.T) {
+	runVectors(t, calc.Big, bigOnlyVectors)
+}
+
+// TestIntBackend_Overflow shows the int backend reports what big computes exactly.
+func TestIntBackend_Overflow(t *testing.T) {
+	for _, tt := range bigOnlyVectors[:2] {
+		t.Run(tt.name, func(t *testing.T) {
+			a, _ := calc.Int.Parse(tt.a)
+			b, _ := calc.Int.Parse(tt.b)
+
+			var err error
+			switch tt.op {
+			case "sum":
+				_, err = calc.Int.Sum(a, b)
+			case "multiply":
+				_, err = calc.Int.Multiply(a, b)
+			}
+			if !errors.Is(err, calc.ErrOverflow) {
+				t.Errorf("%s(%s, %s) error = %v; want ErrOverflow", tt.op, tt.a, tt.b, err)
+			}
+		})
+	}
+}
+
+// TestBigBackend_DoesNotMutateOperands guards the allocation contract.
+func TestBigBackend_DoesNotMutateOperands(t *testing.T) {
+	a, b := big.NewInt(6), big.NewInt(3)
+
+	_, _ = calc.Big.Sum(a, b)
+	_, _ = calc.Big.Multiply(a, b)
+	_, _ = calc.Big.Divide(a, b)
+
+	if a.Int64() != 6 || b.Int64() != 3 {
+		t.Errorf("operands changed to (%s, %s)", a, b)
+	}
+}
+
+// TestBigBackend_ParseError demonstrates rejecting malformed input.
+func TestBigBackend_ParseError(t *testing.T) {
+	if _, err := calc.Big.Parse("12abc"); err == nil {
+		t.Error("Parse(\"12abc\") expected error, got nil")
+	}
+}
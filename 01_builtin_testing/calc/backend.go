@@ -0,0 +1,93 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Operations is the calc operation set, parameterised by number
+// representation. Int and Big implement it for int and *big.Int, so callers
+// choose precision once and write the rest of their code against the same
+// methods.
+type Operations[T any] interface {
+	// Parse converts a base-10 string to a value.
+	Parse(s string) (T, error)
+	// Format converts a value to a base-10 string.
+	Format(v T) string
+
+	Sum(a, b T) (T, error)
+	Subtract(a, b T) (T, error)
+	Multiply(a, b T) (T, error)
+	// Divide returns ErrDivisionByZero for b == 0. Integer backends round
+	// the quotient towards zero.
+	Divide(a, b T) (T, error)
+}
+
+// Int is the machine-int backend. Results that do not fit in an int are
+// reported as *OverflowError rather than wrapping.
+var Int Operations[int] = intOps{}
+
+// Big is the arbitrary-precision backend. It never overflows; operands are
+// not modified and every result is a freshly allocated *big.Int.
+var Big Operations[*big.Int] = bigOps{}
+
+type intOps struct{}
+
+func (intOps) Parse(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+func (intOps) Format(v int) string {
+	return strconv.Itoa(v)
+}
+
+func (intOps) Sum(a, b int) (int, error) {
+	return SumChecked(a, b)
+}
+
+func (intOps) Subtract(a, b int) (int, error) {
+	return SubtractChecked(a, b)
+}
+
+func (intOps) Multiply(a, b int) (int, error) {
+	return MultiplyChecked(a, b)
+}
+
+func (intOps) Divide(a, b int) (int, error) {
+	return DivideChecked(a, b)
+}
+
+type bigOps struct{}
+
+func (bigOps) Parse(s string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid integer %q", s)
+	}
+	return n, nil
+}
+
+func (bigOps) Format(v *big.Int) string {
+	return v.String()
+}
+
+func (bigOps) Sum(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Add(a, b), nil
+}
+
+func (bigOps) Subtract(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Sub(a, b), nil
+}
+
+func (bigOps) Multiply(a, b *big.Int) (*big.Int, error) {
+	return new(big.Int).Mul(a, b), nil
+}
+
+func (bigOps) Divide(a, b *big.Int) (*big.Int, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivisionByZero
+	}
+	// Quo truncates like Go's / operator; Div would round towards -inf.
+	return new(big.Int).Quo(a, b), nil
+}
@@ -0,0 +1,68 @@
+package calc
+
+// Calculator provides basic arithmetic operations plus name-based dispatch
+// through a Registry. It is the single Calculator shared by the Ginkgo,
+// Godog and Gauge examples. The zero value uses DefaultRegistry.
+type Calculator struct {
+	registry *Registry
+}
+
+// NewCalculator creates a Calculator backed by DefaultRegistry.
+func NewCalculator() *Calculator {
+	return &Calculator{registry: DefaultRegistry}
+}
+
+// NewCalculatorWithRegistry creates a Calculator backed by r.
+func NewCalculatorWithRegistry(r *Registry) *Calculator {
+	return &Calculator{registry: r}
+}
+
+// Registry returns the registry used by Apply.
+func (c *Calculator) Registry() *Registry {
+	if c.registry == nil {
+		return DefaultRegistry
+	}
+	return c.registry
+}
+
+// Add returns the sum of two integers.
+func (c *Calculator) Add(a, b int) int {
+	return Sum(a, b)
+}
+
+// Subtract returns the difference between two integers.
+func (c *Calculator) Subtract(a, b int) int {
+	return Subtract(a, b)
+}
+
+// Multiply returns the product of two integers.
+func (c *Calculator) Multiply(a, b int) int {
+	return Multiply(a, b)
+}
+
+// Divide returns the quotient of two integers.
+// Returns ErrDivisionByZero if attempting to divide by zero.
+func (c *Calculator) Divide(a, b int) (int, error) {
+	return DivideChecked(a, b)
+}
+
+// IsPositive checks if a number is positive.
+func (c *Calculator) IsPositive(n int) bool {
+	return n > 0
+}
+
+// Sum returns the sum of a slice of integers.
+func (c *Calculator) Sum(numbers []int) int {
+	total := 0
+	for _, n := range numbers {
+		total += n
+	}
+	return total
+}
+
+// Apply runs the operation registered under name, e.g. "add" or "divide".
+// Unlike Add, Subtract and Multiply, the registered arithmetic is checked
+// and reports overflow as an error.
+func (c *Calculator) Apply(name string, operands ...int) (int, error) {
+	return c.Registry().Apply(name, operands...)
+}
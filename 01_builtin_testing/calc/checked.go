@@ -0,0 +1,67 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
+)
+
+// ErrOverflow is the sentinel wrapped by every OverflowError.
+// Use errors.Is(err, ErrOverflow) to detect any overflow.
+var ErrOverflow = numeric.ErrOverflow
+
+// Error is a simple error type.
+type Error = numeric.Error
+
+// OverflowError reports an operation whose result does not fit in an int.
+type OverflowError = numeric.OverflowError[int]
+
+// SumChecked returns a + b, or an *OverflowError if the result wraps.
+func SumChecked(a, b int) (int, error) {
+	return numeric.SumChecked(a, b)
+}
+
+// SubtractChecked returns a - b, or an *OverflowError if the result wraps.
+func SubtractChecked(a, b int) (int, error) {
+	return numeric.SubtractChecked(a, b)
+}
+
+// MultiplyChecked returns a * b, or an *OverflowError if the result wraps.
+func MultiplyChecked(a, b int) (int, error) {
+	return numeric.MultiplyChecked(a, b)
+}
+
+// SumSaturating returns a + b, clamped to [math.MinInt, math.MaxInt].
+func SumSaturating(a, b int) int {
+	sum, err := SumChecked(a, b)
+	if err != nil {
+		return saturate(b > 0)
+	}
+	return sum
+}
+
+// SubtractSaturating returns a - b, clamped to [math.MinInt, math.MaxInt].
+func SubtractSaturating(a, b int) int {
+	diff, err := SubtractChecked(a, b)
+	if err != nil {
+		return saturate(b < 0)
+	}
+	return diff
+}
+
+// MultiplySaturating returns a * b, clamped to [math.MinInt, math.MaxInt].
+func MultiplySaturating(a, b int) int {
+	product, err := MultiplyChecked(a, b)
+	if err != nil {
+		return saturate((a < 0) == (b < 0))
+	}
+	return product
+}
+
+// saturate returns the bound an overflowing result was heading towards.
+func saturate(positive bool) int {
+	if positive {
+		return math.MaxInt
+	}
+	return math.MinInt
+}
@@ -0,0 +1,219 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidDecimal is wrapped by ParseDecimal errors.
+var ErrInvalidDecimal = Error("invalid decimal")
+
+// RoundingMode selects how Round and Divide discard digits.
+type RoundingMode int
+
+const (
+	// HalfEven rounds to the nearest value, breaking ties towards the even
+	// neighbour ("banker's rounding"). It avoids the upward drift of HalfUp
+	// when summing many rounded amounts.
+	HalfEven RoundingMode = iota
+	// HalfUp rounds to the nearest value, breaking ties away from zero.
+	HalfUp
+	// Down truncates towards zero.
+	Down
+)
+
+// String returns the mode name.
+func (m RoundingMode) String() string {
+	switch m {
+	case HalfEven:
+		return "half-even"
+	case HalfUp:
+		return "half-up"
+	case Down:
+		return "down"
+	default:
+		return "unknown"
+	}
+}
+
+// Decimal is an exact fixed-point number: an arbitrary-precision integer
+// count of units together with a scale, the number of digits after the
+// decimal point. 12.30 is 1230 units at scale 2.
+//
+// Add, Subtract and Multiply are exact; use Round to bring a result back to
+// the scale you need. Divide takes the result scale and rounding mode
+// explicitly. The zero value is 0 at scale 0.
+type Decimal struct {
+	units *big.Int
+	scale int
+}
+
+// NewDecimal returns units × 10^-scale. A negative scale is treated as zero.
+func NewDecimal(units int64, scale int) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	return Decimal{units: big.NewInt(units), scale: scale}
+}
+
+// ParseDecimal parses a string such as "-12.30". The scale of the result is
+// the number of digits written after the decimal point.
+func ParseDecimal(s string) (Decimal, error) {
+	digits := s
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		digits = s[1:]
+	}
+	whole, frac, hasPoint := strings.Cut(digits, ".")
+	if whole == "" || (hasPoint && frac == "") || !allDigits(whole) || !allDigits(frac) {
+		return Decimal{}, fmt.Errorf("%w %q", ErrInvalidDecimal, s)
+	}
+
+	units, _ := new(big.Int).SetString(whole+frac, 10)
+	if strings.HasPrefix(s, "-") {
+		units.Neg(units)
+	}
+	return Decimal{units: units, scale: len(frac)}, nil
+}
+
+func allDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// MustParseDecimal is like ParseDecimal but panics on malformed input.
+// It is intended for constants in tests and examples.
+func MustParseDecimal(s string) Decimal {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or +1.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// Cmp compares d and other numerically, ignoring scale: 1.5 equals 1.50.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := align(d, other)
+	return a.Cmp(b)
+}
+
+// String formats d with exactly Scale digits after the decimal point.
+func (d Decimal) String() string {
+	digits := new(big.Int).Abs(d.int()).String()
+	if d.scale > 0 {
+		if len(digits) <= d.scale {
+			digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-d.scale] + "." + digits[len(digits)-d.scale:]
+	}
+	if d.Sign() < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Add returns d + other exactly, at the larger of the two scales.
+func (d Decimal) Add(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{units: a.Add(a, b), scale: max(d.scale, other.scale)}
+}
+
+// Subtract returns d - other exactly, at the larger of the two scales.
+func (d Decimal) Subtract(other Decimal) Decimal {
+	a, b := align(d, other)
+	return Decimal{units: a.Sub(a, b), scale: max(d.scale, other.scale)}
+}
+
+// Multiply returns d × other exactly, at the sum of the two scales.
+func (d Decimal) Multiply(other Decimal) Decimal {
+	return Decimal{units: new(big.Int).Mul(d.int(), other.int()), scale: d.scale + other.scale}
+}
+
+// Divide returns d / other rounded to scale digits using mode.
+// It returns ErrDivisionByZero if other is zero.
+func (d Decimal) Divide(other Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if other.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	// d/other = (du / 10^ds) / (ou / 10^os), so the units at the target
+	// scale are du × 10^(os+scale) / (ou × 10^ds).
+	num := new(big.Int).Mul(d.int(), pow10(other.scale+scale))
+	den := new(big.Int).Mul(other.int(), pow10(d.scale))
+	return Decimal{units: roundQuo(num, den, mode), scale: scale}, nil
+}
+
+// Round returns d with exactly scale digits after the decimal point,
+// discarding digits according to mode. A negative scale is treated as zero.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	if scale < 0 {
+		scale = 0
+	}
+	if scale >= d.scale {
+		return Decimal{units: new(big.Int).Mul(d.int(), pow10(scale-d.scale)), scale: scale}
+	}
+	return Decimal{units: roundQuo(d.int(), pow10(d.scale-scale), mode), scale: scale}
+}
+
+// int returns the units, treating the zero value as 0.
+func (d Decimal) int() *big.Int {
+	if d.units == nil {
+		return new(big.Int)
+	}
+	return d.units
+}
+
+// align returns copies of the units of a and b rescaled to a common scale.
+func align(a, b Decimal) (*big.Int, *big.Int) {
+	x := new(big.Int).Set(a.int())
+	y := new(big.Int).Set(b.int())
+	if a.scale < b.scale {
+		x.Mul(x, pow10(b.scale-a.scale))
+	} else if b.scale < a.scale {
+		y.Mul(y, pow10(a.scale-b.scale))
+	}
+	return x, y
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// roundQuo returns num / den rounded to an integer using mode.
+func roundQuo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 || mode == Down {
+		return q
+	}
+
+	// Compare the discarded fraction |r/den| against one half.
+	half := new(big.Int).Abs(r)
+	half.Lsh(half, 1)
+	cmp := half.Cmp(new(big.Int).Abs(den))
+
+	if cmp > 0 || (cmp == 0 && (mode == HalfUp || q.Bit(0) == 1)) {
+		// Step away from zero, in the direction of the true quotient.
+		if (num.Sign() < 0) != (den.Sign() < 0) {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q
+}
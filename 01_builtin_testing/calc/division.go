@@ -0,0 +1,103 @@
+package calc
+
+import (
+	"math"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
+)
+
+// ErrDivisionByZero is returned when dividing by zero.
+var ErrDivisionByZero = numeric.ErrDivisionByZero
+
+// ErrUnknownDivisionMode is returned for a DivisionMode outside the
+// defined constants.
+var ErrUnknownDivisionMode = Error("unknown division mode")
+
+// DivisionMode selects how a quotient is rounded when the division is inexact.
+// The modes differ only when an operand is negative.
+type DivisionMode int
+
+const (
+	// Truncated rounds the quotient towards zero; the remainder has the
+	// sign of the dividend. This is Go's native / and % behaviour.
+	Truncated DivisionMode = iota
+	// Floored rounds the quotient towards negative infinity; the remainder
+	// has the sign of the divisor.
+	Floored
+	// Euclidean chooses the quotient so that the remainder is never negative.
+	Euclidean
+)
+
+// String returns the mode name.
+func (m DivisionMode) String() string {
+	switch m {
+	case Truncated:
+		return "truncated"
+	case Floored:
+		return "floored"
+	case Euclidean:
+		return "euclidean"
+	default:
+		return "unknown"
+	}
+}
+
+func (m DivisionMode) valid() bool {
+	return m >= Truncated && m <= Euclidean
+}
+
+// DivideChecked returns a / b rounded towards zero.
+// Unlike Divide it reports ErrDivisionByZero instead of returning 0, and an
+// *OverflowError for math.MinInt / -1.
+func DivideChecked(a, b int) (int, error) {
+	return numeric.DivideChecked(a, b)
+}
+
+// DivMod returns the quotient and remainder of a / b under the given mode.
+// The results always satisfy a == q*b + r with |r| < |b|.
+func DivMod(a, b int, mode DivisionMode) (q, r int, err error) {
+	if !mode.valid() {
+		return 0, 0, ErrUnknownDivisionMode
+	}
+	if b == 0 {
+		return 0, 0, ErrDivisionByZero
+	}
+	if a == math.MinInt && b == -1 {
+		return 0, 0, &OverflowError{Op: "divide", A: a, B: b}
+	}
+
+	q, r = a/b, a%b
+	switch mode {
+	case Truncated:
+	case Floored:
+		if r != 0 && (r < 0) != (b < 0) {
+			q--
+			r += b
+		}
+	case Euclidean:
+		if r < 0 {
+			if b > 0 {
+				q--
+				r += b
+			} else {
+				q++
+				r -= b
+			}
+		}
+	}
+	return q, r, nil
+}
+
+// Mod returns the remainder of a / b under the given mode.
+func Mod(a, b int, mode DivisionMode) (int, error) {
+	if !mode.valid() {
+		return 0, ErrUnknownDivisionMode
+	}
+	if b == -1 {
+		// Every mode agrees the remainder is zero, and this avoids
+		// reporting overflow for math.MinInt % -1.
+		return 0, nil
+	}
+	_, r, err := DivMod(a, b, mode)
+	return r, err
+}
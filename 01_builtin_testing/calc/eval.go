@@ -0,0 +1,435 @@
+package calc
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// SyntaxError reports malformed input to Eval.
+type SyntaxError struct {
+	Pos int // 1-based byte offset of the offending character
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// UndefinedError reports a variable that is not in the environment.
+type UndefinedError struct {
+	Pos  int // 1-based byte offset of the variable name
+	Name string
+}
+
+func (e *UndefinedError) Error() string {
+	return fmt.Sprintf("position %d: undefined variable %q", e.Pos, e.Name)
+}
+
+// CallError reports a function call in an expression that names an unknown
+// operation or passes the wrong number of arguments. It unwraps to the
+// *UnknownOperationError or *ArityError from the registry.
+type CallError struct {
+	Pos int // 1-based byte offset of the function name
+	Err error
+}
+
+func (e *CallError) Error() string {
+	return fmt.Sprintf("position %d: %v", e.Pos, e.Err)
+}
+
+func (e *CallError) Unwrap() error {
+	return e.Err
+}
+
+// Eval parses and evaluates an integer arithmetic expression such as
+// "(2 + 3) * -4 / 2".
+//
+// It supports + - * / with the usual precedence, parentheses, unary minus
+// and calls to operations in DefaultRegistry such as "gcd(12, 18)".
+// Arithmetic uses the checked operations, so division by zero yields
+// ErrDivisionByZero and overflow yields an *OverflowError. Malformed input
+// yields a *SyntaxError carrying the position of the problem.
+func Eval(expr string) (int, error) {
+	return EvalEnv(expr, nil)
+}
+
+// EvalEnv is like Eval but resolves identifiers such as "x" or "ans" from
+// vars. Unknown names yield an *UndefinedError.
+func EvalEnv(expr string, vars map[string]int) (int, error) {
+	return evaluate(expr, env{vars: vars, registry: DefaultRegistry})
+}
+
+// Eval is like EvalEnv but resolves function calls in c's registry.
+func (c *Calculator) Eval(expr string, vars map[string]int) (int, error) {
+	return evaluate(expr, env{vars: vars, registry: c.Registry()})
+}
+
+func evaluate(expr string, e env) (int, error) {
+	p := &parser{lex: lexer{src: expr}}
+	p.next()
+
+	root, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	if p.tok.kind != tokEOF {
+		return 0, p.errorf("unexpected %s", p.tok)
+	}
+	return root.eval(e)
+}
+
+// IsIdentifier reports whether name can be used as a variable in EvalEnv.
+func IsIdentifier(name string) bool {
+	if name == "" || !isIdentStart(name[0]) {
+		return false
+	}
+	for i := 1; i < len(name); i++ {
+		if !isIdentStart(name[i]) && !isDigit(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Lexer
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOperator
+	tokLParen
+	tokRParen
+	tokComma
+	tokIllegal
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int // 1-based
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	return strconv.Quote(t.text)
+}
+
+type lexer struct {
+	src string
+	off int // 0-based read offset
+}
+
+func (l *lexer) next() token {
+	for l.off < len(l.src) && isSpace(l.src[l.off]) {
+		l.off++
+	}
+	start := l.off
+	pos := start + 1
+	if l.off >= len(l.src) {
+		return token{kind: tokEOF, pos: pos}
+	}
+
+	c := l.src[l.off]
+	switch {
+	case isDigit(c):
+		for l.off < len(l.src) && isDigit(l.src[l.off]) {
+			l.off++
+		}
+		return token{kind: tokNumber, text: l.src[start:l.off], pos: pos}
+	case isIdentStart(c):
+		for l.off < len(l.src) && (isIdentStart(l.src[l.off]) || isDigit(l.src[l.off])) {
+			l.off++
+		}
+		return token{kind: tokIdent, text: l.src[start:l.off], pos: pos}
+	case c == '+' || c == '-' || c == '*' || c == '/':
+		l.off++
+		return token{kind: tokOperator, text: string(c), pos: pos}
+	case c == '(':
+		l.off++
+		return token{kind: tokLParen, text: "(", pos: pos}
+	case c == ')':
+		l.off++
+		return token{kind: tokRParen, text: ")", pos: pos}
+	case c == ',':
+		l.off++
+		return token{kind: tokComma, text: ",", pos: pos}
+	}
+	l.off++
+	return token{kind: tokIllegal, text: string(c), pos: pos}
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// Parser
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/") unary }
+//	unary   = "-" unary | primary
+//	primary = number | identifier [ call ] | "(" expr ")"
+//	call    = "(" [ expr { "," expr } ] ")"
+
+type parser struct {
+	lex lexer
+	tok token
+}
+
+func (p *parser) next() {
+	p.tok = p.lex.next()
+}
+
+// errorf reports an error at the current token. An illegal character
+// always takes precedence over the message the caller had in mind.
+func (p *parser) errorf(format string, args ...interface{}) error {
+	if p.tok.kind == tokIllegal {
+		return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf("unexpected character %s", p.tok)}
+	}
+	return &SyntaxError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isOperator(ops string) bool {
+	if p.tok.kind != tokOperator {
+		return false
+	}
+	for i := 0; i < len(ops); i++ {
+		if p.tok.text[0] == ops[i] {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("+-") {
+		op := p.tok
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOperator("*/") {
+		op := p.tok
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: op.text[0], left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.isOperator("-") {
+		p.next()
+		if p.tok.kind == tokNumber {
+			// Fold the sign into the literal, so that math.MinInt, whose
+			// magnitude does not fit in an int, can be written.
+			return p.parseNumber("-")
+		}
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negateNode{operand: operand}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	switch p.tok.kind {
+	case tokNumber:
+		return p.parseNumber("")
+	case tokIdent:
+		ident := p.tok
+		p.next()
+		if p.tok.kind == tokLParen {
+			return p.parseCall(ident)
+		}
+		return &identNode{name: ident.text, pos: ident.pos}, nil
+	case tokLParen:
+		open := p.tok
+		p.next()
+		inner, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectClose(open); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+	return nil, p.errorf("expected number, variable or \"(\", found %s", p.tok)
+}
+
+// parseNumber parses the current number token, preceded by sign.
+func (p *parser) parseNumber(sign string) (node, error) {
+	n, err := strconv.Atoi(sign + p.tok.text)
+	if err != nil {
+		return nil, p.errorf("number %s%s out of range", sign, p.tok.text)
+	}
+	p.next()
+	return numberNode(n), nil
+}
+
+// parseCall parses the argument list of a call to name. The current token
+// is the opening parenthesis.
+func (p *parser) parseCall(name token) (node, error) {
+	open := p.tok
+	p.next()
+
+	call := &callNode{name: name.text, pos: name.pos}
+	if p.tok.kind != tokRParen {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			call.args = append(call.args, arg)
+			if p.tok.kind != tokComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if err := p.expectClose(open); err != nil {
+		return nil, err
+	}
+	return call, nil
+}
+
+// expectClose consumes the ")" matching open.
+func (p *parser) expectClose(open token) error {
+	if p.tok.kind != tokRParen {
+		if p.tok.kind == tokEOF {
+			return &SyntaxError{Pos: open.pos, Msg: "unclosed parenthesis"}
+		}
+		return p.errorf("expected \")\", found %s", p.tok)
+	}
+	p.next()
+	return nil
+}
+
+// Syntax tree
+
+// env is what an expression is evaluated against.
+type env struct {
+	vars     map[string]int
+	registry *Registry
+}
+
+type node interface {
+	eval(e env) (int, error)
+}
+
+type numberNode int
+
+func (n numberNode) eval(env) (int, error) {
+	return int(n), nil
+}
+
+type identNode struct {
+	name string
+	pos  int
+}
+
+func (n *identNode) eval(e env) (int, error) {
+	v, ok := e.vars[n.name]
+	if !ok {
+		return 0, &UndefinedError{Pos: n.pos, Name: n.name}
+	}
+	return v, nil
+}
+
+type negateNode struct {
+	operand node
+}
+
+func (n *negateNode) eval(e env) (int, error) {
+	v, err := n.operand.eval(e)
+	if err != nil {
+		return 0, err
+	}
+	return SubtractChecked(0, v)
+}
+
+type binaryNode struct {
+	op          byte
+	left, right node
+}
+
+func (n *binaryNode) eval(e env) (int, error) {
+	a, err := n.left.eval(e)
+	if err != nil {
+		return 0, err
+	}
+	b, err := n.right.eval(e)
+	if err != nil {
+		return 0, err
+	}
+
+	switch n.op {
+	case '+':
+		return SumChecked(a, b)
+	case '-':
+		return SubtractChecked(a, b)
+	case '*':
+		return MultiplyChecked(a, b)
+	default:
+		return DivideChecked(a, b)
+	}
+}
+
+type callNode struct {
+	name string
+	pos  int
+	args []node
+}
+
+func (n *callNode) eval(e env) (int, error) {
+	op, ok := e.registry.Lookup(n.name)
+	if !ok {
+		return 0, &CallError{Pos: n.pos, Err: &UnknownOperationError{Name: n.name}}
+	}
+	if len(n.args) != op.Arity {
+		return 0, &CallError{Pos: n.pos, Err: &ArityError{Name: n.name, Want: op.Arity, Got: len(n.args)}}
+	}
+
+	operands := make([]int, len(n.args))
+	for i, arg := range n.args {
+		v, err := arg.eval(e)
+		if err != nil {
+			return 0, err
+		}
+		operands[i] = v
+	}
+	return e.registry.Apply(n.name, operands...)
+}
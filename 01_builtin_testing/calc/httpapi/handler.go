@@ -0,0 +1,167 @@
+// Package httpapi serves calculator operations over HTTP, so other
+// services get exactly the arithmetic the test suites verify.
+//
+// POST /calc accepts either a named operation or an expression:
+//
+//	{"op": "add", "operands": [2, 3]}
+//	{"expression": "2 * (3 + 4)"}
+//
+// and answers with {"result": N}, or with an error object such as
+//
+//	{"error": {"code": "division_by_zero", "message": "cannot divide by zero"}}
+package httpapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// maxBodyBytes bounds the size of a request body.
+const maxBodyBytes = 1 << 20
+
+// Error codes reported in Error.Code.
+const (
+	CodeInvalidRequest    = "invalid_request"
+	CodeMethodNotAllowed  = "method_not_allowed"
+	CodeSyntaxError       = "syntax_error"
+	CodeUndefinedVariable = "undefined_variable"
+	CodeUnknownOperation  = "unknown_operation"
+	CodeArity             = "wrong_arity"
+	CodeDivisionByZero    = "division_by_zero"
+	CodeOverflow          = "overflow"
+	CodeDomain            = "domain_error"
+	CodeCalculation       = "calculation_error"
+)
+
+// Request is the body of POST /calc. Exactly one of Op and Expression
+// must be set.
+type Request struct {
+	Op         string `json:"op,omitempty"`
+	Operands   []int  `json:"operands,omitempty"`
+	Expression string `json:"expression,omitempty"`
+}
+
+// Response is the body of every /calc response.
+type Response struct {
+	Result *int   `json:"result,omitempty"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// Error describes a failed request.
+type Error struct {
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Position int    `json:"position,omitempty"` // 1-based, for expression errors
+}
+
+// NewHandler returns a handler serving POST /calc with c.
+func NewHandler(c *calc.Calculator) http.Handler {
+	mux := http.NewServeMux()
+
+	// POST /calc - Apply an operation or evaluate an expression
+	mux.HandleFunc("/calc", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, http.StatusMethodNotAllowed, &Error{Code: CodeMethodNotAllowed, Message: "method not allowed"})
+			return
+		}
+
+		var req Request
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, &Error{Code: CodeInvalidRequest, Message: fmt.Sprintf("invalid JSON: %v", err)})
+			return
+		}
+
+		var result int
+		var err error
+		switch {
+		case req.Op != "" && req.Expression != "":
+			err = errInvalidRequest("set either op or expression, not both")
+		case req.Op != "":
+			result, err = c.Apply(req.Op, req.Operands...)
+		case req.Expression != "":
+			if req.Operands != nil {
+				err = errInvalidRequest("operands cannot be used with expression")
+				break
+			}
+			result, err = c.Eval(req.Expression, nil)
+		default:
+			err = errInvalidRequest("missing op or expression")
+		}
+
+		if err != nil {
+			status, e := classify(err)
+			writeError(w, status, e)
+			return
+		}
+		writeJSON(w, http.StatusOK, Response{Result: &result})
+	})
+
+	return mux
+}
+
+// requestError is a well-formed JSON body that is not a valid request.
+type requestError string
+
+func (e requestError) Error() string { return string(e) }
+
+func errInvalidRequest(msg string) error { return requestError(msg) }
+
+// classify maps an error to an HTTP status and an Error. Bad input is a
+// 400; arithmetic that fails on valid input is a 422.
+func classify(err error) (int, *Error) {
+	e := &Error{Message: err.Error()}
+
+	var re requestError
+	var se *calc.SyntaxError
+	var ude *calc.UndefinedError
+	var uoe *calc.UnknownOperationError
+	var ae *calc.ArityError
+	var ce *calc.CallError
+	if errors.As(err, &ce) {
+		e.Position = ce.Pos
+	}
+
+	status := http.StatusBadRequest
+	switch {
+	case errors.As(err, &re):
+		e.Code = CodeInvalidRequest
+	case errors.As(err, &se):
+		e.Code, e.Position = CodeSyntaxError, se.Pos
+	case errors.As(err, &ude):
+		e.Code, e.Position = CodeUndefinedVariable, ude.Pos
+	case errors.As(err, &uoe):
+		e.Code = CodeUnknownOperation
+	case errors.As(err, &ae):
+		e.Code = CodeArity
+	default:
+		status = http.StatusUnprocessableEntity
+		switch {
+		case errors.Is(err, calc.ErrDivisionByZero):
+			e.Code = CodeDivisionByZero
+		case errors.Is(err, calc.ErrOverflow):
+			e.Code = CodeOverflow
+		case errors.Is(err, calc.ErrDomain):
+			e.Code = CodeDomain
+		default:
+			e.Code = CodeCalculation
+		}
+	}
+	return status, e
+}
+
+func writeError(w http.ResponseWriter, status int, e *Error) {
+	writeJSON(w, status, Response{Error: e})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body Response) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body) // Error ignored - the client has gone away
+}
@@ -0,0 +1,310 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrEmptyBatch is returned by Batch when given no calls. The server would
+// answer an empty batch with an Invalid Request error, so it is never sent.
+var ErrEmptyBatch = errors.New("jsonrpc: empty batch")
+
+// transport carries one encoded message and, if a reply is expected,
+// returns it.
+type transport interface {
+	roundTrip(ctx context.Context, msg []byte, wantReply bool) ([]byte, error)
+	close() error
+}
+
+// Client calls a calculator JSON-RPC server. It is safe for concurrent
+// use; calls over TCP are serialised on the connection.
+type Client struct {
+	t      transport
+	nextID atomic.Int64
+}
+
+// NewHTTPClient returns a Client that POSTs to url. If hc is nil,
+// http.DefaultClient is used.
+func NewHTTPClient(url string, hc *http.Client) *Client {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	return &Client{t: &httpTransport{url: url, client: hc}}
+}
+
+// Dial connects to a server started with Server.Serve. If a call's context
+// is cancelled mid-call, its reply may still arrive later and make the next
+// call fail with a *ProtocolError, so close the client after a cancellation.
+func Dial(ctx context.Context, network, addr string) (*Client, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, addr)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{t: &connTransport{conn: conn, dec: json.NewDecoder(bufio.NewReader(conn))}}, nil
+}
+
+// Close releases the client's connection, if it has one.
+func (c *Client) Close() error {
+	return c.t.close()
+}
+
+// Call invokes method with operands as positional params. Failed
+// calculations return an *Error; application errors unwrap to the calc
+// sentinels, so errors.Is(err, calc.ErrDivisionByZero) works.
+func (c *Client) Call(ctx context.Context, method string, operands ...int) (int, error) {
+	return c.call(ctx, method, operandsOrEmpty(operands))
+}
+
+// Eval evaluates an expression on the server.
+func (c *Client) Eval(ctx context.Context, expr string) (int, error) {
+	return c.call(ctx, EvalMethod, []string{expr})
+}
+
+// Notify invokes method without waiting for, or receiving, a result. A
+// notification with no method is an Invalid Request the server must answer,
+// so that reply is read and returned as the error.
+func (c *Client) Notify(ctx context.Context, method string, operands ...int) error {
+	req, err := newRequest(method, operandsOrEmpty(operands), nil)
+	if err != nil {
+		return err
+	}
+	replyDue := method == ""
+	reply, err := c.t.roundTrip(ctx, mustMarshal(req), replyDue)
+	if err != nil || !replyDue {
+		return err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(reply, &resp); err != nil {
+		return &ProtocolError{Msg: fmt.Sprintf("invalid response: %v", err)}
+	}
+	if resp.Error == nil {
+		return &ProtocolError{Msg: "notification answered without an error"}
+	}
+	return resp.Error
+}
+
+func (c *Client) call(ctx context.Context, method string, params interface{}) (int, error) {
+	id := c.newID()
+	req, err := newRequest(method, params, id)
+	if err != nil {
+		return 0, err
+	}
+	reply, err := c.t.roundTrip(ctx, mustMarshal(req), true)
+	if err != nil {
+		return 0, err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(reply, &resp); err != nil {
+		return 0, &ProtocolError{Msg: fmt.Sprintf("invalid response: %v", err)}
+	}
+	if !bytes.Equal(resp.ID, id) {
+		if resp.Error != nil {
+			return 0, resp.Error // the server could not read the request's id
+		}
+		return 0, &ProtocolError{Msg: fmt.Sprintf("response id %s does not match request id %s", resp.ID, id)}
+	}
+	return resp.result()
+}
+
+// BatchCall is one entry in a batch. Notify entries get no result.
+type BatchCall struct {
+	Method   string
+	Operands []int
+	Notify   bool
+}
+
+// BatchResult is the outcome of one non-notification BatchCall.
+type BatchResult struct {
+	Result int
+	Err    error
+}
+
+// Batch sends calls in a single batch and returns one result per call,
+// in order. Results for notifications are always zero. An empty batch
+// returns ErrEmptyBatch without contacting the server.
+func (c *Client) Batch(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	if len(calls) == 0 {
+		return nil, ErrEmptyBatch
+	}
+	reqs := make([]*Request, len(calls))
+	index := make(map[string]int) // request id -> position in calls
+	replyDue := false             // the server must answer, so the reply is read
+	for i, call := range calls {
+		var id json.RawMessage
+		if !call.Notify {
+			id = c.newID()
+			index[string(id)] = i
+		}
+		if !call.Notify || call.Method == "" {
+			replyDue = true
+		}
+		req, err := newRequest(call.Method, operandsOrEmpty(call.Operands), id)
+		if err != nil {
+			return nil, err
+		}
+		reqs[i] = req
+	}
+
+	reply, err := c.t.roundTrip(ctx, mustMarshal(reqs), replyDue)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]BatchResult, len(calls))
+	if !replyDue {
+		return results, nil
+	}
+
+	var resps []Response
+	if err := json.Unmarshal(reply, &resps); err != nil {
+		var single Response
+		if json.Unmarshal(reply, &single) == nil && single.Error != nil {
+			return nil, single.Error // the batch as a whole was rejected
+		}
+		return nil, &ProtocolError{Msg: fmt.Sprintf("invalid batch response: %v", err)}
+	}
+	var rejected *Error // an entry the server could not read, so has no id
+	for _, resp := range resps {
+		if bytes.Equal(resp.ID, nullID) && resp.Error != nil {
+			if rejected == nil {
+				rejected = resp.Error
+			}
+			continue
+		}
+		i, ok := index[string(resp.ID)]
+		if !ok {
+			return nil, &ProtocolError{Msg: fmt.Sprintf("unexpected response id %s", resp.ID)}
+		}
+		delete(index, string(resp.ID))
+		results[i].Result, results[i].Err = resp.result()
+	}
+	if rejected != nil {
+		return nil, rejected
+	}
+	if len(index) > 0 {
+		return nil, &ProtocolError{Msg: fmt.Sprintf("missing %d responses in batch", len(index))}
+	}
+	return results, nil
+}
+
+func (c *Client) newID() json.RawMessage {
+	return json.RawMessage(strconv.FormatInt(c.nextID.Add(1), 10))
+}
+
+func newRequest(method string, params interface{}, id json.RawMessage) (*Request, error) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &Request{JSONRPC: Version, Method: method, Params: raw, ID: id}, nil
+}
+
+// operandsOrEmpty makes nil operands encode as [] rather than null.
+func operandsOrEmpty(operands []int) []int {
+	if operands == nil {
+		return []int{}
+	}
+	return operands
+}
+
+func (r *Response) result() (int, error) {
+	if r.Error != nil {
+		return 0, r.Error
+	}
+	if r.Result == nil {
+		return 0, &ProtocolError{Msg: "response has neither result nor error"}
+	}
+	return *r.Result, nil
+}
+
+type httpTransport struct {
+	url    string
+	client *http.Client
+}
+
+func (t *httpTransport) roundTrip(ctx context.Context, msg []byte, wantReply bool) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(msg))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNoContent && !wantReply:
+		return nil, nil
+	case resp.StatusCode != http.StatusOK:
+		return nil, &ProtocolError{Msg: fmt.Sprintf("unexpected HTTP status %s", resp.Status)}
+	}
+	return body, nil
+}
+
+func (t *httpTransport) close() error {
+	return nil
+}
+
+type connTransport struct {
+	mu   sync.Mutex
+	conn net.Conn
+	dec  *json.Decoder
+}
+
+func (t *connTransport) roundTrip(ctx context.Context, msg []byte, wantReply bool) ([]byte, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	deadline, _ := ctx.Deadline() // the zero time clears any earlier deadline
+	if err := t.conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = t.conn.SetDeadline(time.Unix(1, 0)) // unblock pending I/O
+	})
+	defer stop()
+
+	if err := writeLine(t.conn, msg); err != nil {
+		return nil, contextErr(ctx, err)
+	}
+	if !wantReply {
+		return nil, nil
+	}
+	var reply json.RawMessage
+	if err := t.dec.Decode(&reply); err != nil {
+		return nil, contextErr(ctx, err)
+	}
+	return reply, nil
+}
+
+// contextErr prefers the context's error when it caused err.
+func contextErr(ctx context.Context, err error) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
+func (t *connTransport) close() error {
+	return t.conn.Close()
+}
@@ -0,0 +1,99 @@
+// Package jsonrpc exposes calculator operations as a JSON-RPC 2.0 service
+// over HTTP and raw TCP, and provides a matching client.
+//
+// Every operation in the calculator's registry is a method taking its
+// operands as positional params, or as {"operands": [...]}:
+//
+//	{"jsonrpc": "2.0", "method": "add", "params": [2, 3], "id": 1}
+//
+// The "eval" method takes an expression, as ["2 * (3 + 4)"] or
+// {"expression": "2 * (3 + 4)"}. Batches and notifications are supported.
+// Over TCP, messages are JSON values separated by newlines.
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// Version is the protocol version sent and required in the jsonrpc member.
+const Version = "2.0"
+
+// Standard JSON-RPC 2.0 error codes.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+)
+
+// Application error codes for failed calculations.
+const (
+	CodeCalculation     = 1000 // any other calculation error
+	CodeDivisionByZero  = 1001
+	CodeOverflow        = 1002
+	CodeDomain          = 1003
+	CodeExpressionError = 1004 // syntax error or undefined variable in eval
+)
+
+// EvalMethod is the method name that evaluates an expression.
+const EvalMethod = "eval"
+
+// Request is a JSON-RPC request or, when ID is empty, a notification.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// Response is a JSON-RPC response. Exactly one of Result and Error is set.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  *int            `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// Error is a JSON-RPC error object. It implements error, and application
+// errors unwrap to the matching calc sentinel, so
+// errors.Is(err, calc.ErrDivisionByZero) works on the client side.
+type Error struct {
+	Code    int        `json:"code"`
+	Message string     `json:"message"`
+	Data    *ErrorData `json:"data,omitempty"`
+}
+
+// ErrorData carries extra detail for expression errors.
+type ErrorData struct {
+	Position int `json:"position,omitempty"` // 1-based
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	switch e.Code {
+	case CodeDivisionByZero:
+		return calc.ErrDivisionByZero
+	case CodeOverflow:
+		return calc.ErrOverflow
+	case CodeDomain:
+		return calc.ErrDomain
+	}
+	return nil
+}
+
+// ProtocolError reports a malformed exchange that is not a JSON-RPC error,
+// such as a response whose id matches no request.
+type ProtocolError struct {
+	Msg string
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("jsonrpc: %s", e.Msg)
+}
@@ -0,0 +1,344 @@
+package jsonrpc
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// maxBodyBytes bounds the size of an HTTP request body.
+const maxBodyBytes = 1 << 20
+
+var nullID = json.RawMessage("null")
+
+// Server answers JSON-RPC requests with a Calculator. It is an
+// http.Handler and can also serve raw TCP connections with Serve.
+// A Server is safe for concurrent use.
+type Server struct {
+	calc *calc.Calculator
+
+	mu        sync.Mutex
+	listeners map[net.Listener]struct{}
+	conns     map[net.Conn]struct{}
+	closed    bool
+}
+
+// NewServer creates a Server that runs operations on c.
+func NewServer(c *calc.Calculator) *Server {
+	return &Server{
+		calc:      c,
+		listeners: make(map[net.Listener]struct{}),
+		conns:     make(map[net.Conn]struct{}),
+	}
+}
+
+// ServeHTTP handles a single request or a batch POSTed as the body. When
+// there is nothing to answer, because every call was a notification, it
+// replies 204 No Content.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "Request body too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	reply := s.Handle(body)
+	if reply == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(reply) // Error ignored - the client has gone away
+}
+
+// Serve accepts TCP connections on l and serves each in its own goroutine
+// until l fails or the Server is closed. After Close it returns nil.
+func (s *Server) Serve(l net.Listener) error {
+	if !s.track(l, nil) {
+		return net.ErrClosed
+	}
+	defer s.untrack(l, nil)
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return nil
+			}
+			return err
+		}
+		if !s.track(nil, conn) {
+			conn.Close()
+			return nil
+		}
+		go func() {
+			defer s.untrack(nil, conn)
+			defer conn.Close()
+			s.ServeConn(conn)
+		}()
+	}
+}
+
+// ServeConn reads newline-separated JSON messages from rw and writes each
+// reply followed by a newline. It returns when rw reaches EOF or sends
+// invalid JSON, which cannot be resynchronised.
+func (s *Server) ServeConn(rw io.ReadWriter) {
+	dec := json.NewDecoder(bufio.NewReader(rw))
+	for {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				_ = writeLine(rw, mustMarshal(errorResponse(nullID, CodeParseError, "Parse error")))
+			}
+			return
+		}
+		if reply := s.Handle(raw); reply != nil {
+			if err := writeLine(rw, reply); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeLine(w io.Writer, msg []byte) error {
+	_, err := w.Write(append(msg, '\n'))
+	return err
+}
+
+// Close stops every Serve loop and closes their open connections.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	for l := range s.listeners {
+		l.Close()
+	}
+	for c := range s.conns {
+		c.Close()
+	}
+	return nil
+}
+
+func (s *Server) track(l net.Listener, c net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	if l != nil {
+		s.listeners[l] = struct{}{}
+	}
+	if c != nil {
+		s.conns[c] = struct{}{}
+	}
+	return true
+}
+
+func (s *Server) untrack(l net.Listener, c net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.listeners, l)
+	delete(s.conns, c)
+}
+
+func (s *Server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Handle answers one JSON-RPC message, a single request or a batch, and
+// returns the encoded reply. It returns nil when no reply is due.
+func (s *Server) Handle(msg []byte) []byte {
+	msg = bytes.TrimSpace(msg)
+	if !json.Valid(msg) {
+		return mustMarshal(errorResponse(nullID, CodeParseError, "Parse error"))
+	}
+	if len(msg) == 0 || msg[0] != '[' {
+		if resp := s.handleRequest(msg); resp != nil {
+			return mustMarshal(resp)
+		}
+		return nil
+	}
+
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil || len(batch) == 0 {
+		return mustMarshal(errorResponse(nullID, CodeInvalidRequest, "Invalid Request"))
+	}
+	var replies []*Response
+	for _, raw := range batch {
+		if resp := s.handleRequest(raw); resp != nil {
+			replies = append(replies, resp)
+		}
+	}
+	if len(replies) == 0 {
+		return nil
+	}
+	return mustMarshal(replies)
+}
+
+// handleRequest answers one request, or returns nil for a notification.
+func (s *Server) handleRequest(raw json.RawMessage) *Response {
+	var req Request
+	if err := json.Unmarshal(raw, &req); err != nil {
+		return errorResponse(nullID, CodeInvalidRequest, "Invalid Request")
+	}
+	if !validID(req.ID) {
+		return errorResponse(nullID, CodeInvalidRequest, "Invalid Request")
+	}
+	id := req.ID
+	if len(id) == 0 {
+		id = nullID
+	}
+	if req.JSONRPC != Version || req.Method == "" {
+		return errorResponse(id, CodeInvalidRequest, "Invalid Request")
+	}
+
+	result, rpcErr := s.call(req.Method, req.Params)
+	if len(req.ID) == 0 {
+		return nil // a notification: run it, but never reply
+	}
+	if rpcErr != nil {
+		return &Response{JSONRPC: Version, Error: rpcErr, ID: id}
+	}
+	return &Response{JSONRPC: Version, Result: &result, ID: id}
+}
+
+// validID reports whether id is absent, a string, a number or null.
+func validID(id json.RawMessage) bool {
+	if len(id) == 0 {
+		return true
+	}
+	switch id[0] {
+	case '"', 'n', '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return true
+	}
+	return false
+}
+
+func (s *Server) call(method string, params json.RawMessage) (int, *Error) {
+	if method == EvalMethod {
+		expr, err := evalParams(params)
+		if err != nil {
+			return 0, &Error{Code: CodeInvalidParams, Message: err.Error()}
+		}
+		result, err := s.calc.Eval(expr, nil)
+		return result, toError(err)
+	}
+
+	operands, err := operandParams(params)
+	if err != nil {
+		return 0, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	result, err := s.calc.Apply(method, operands...)
+	return result, toError(err)
+}
+
+// operandParams accepts [1, 2], {"operands": [1, 2]} or no params.
+func operandParams(params json.RawMessage) ([]int, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	var operands []int
+	if params[0] == '{' {
+		var named struct {
+			Operands []int `json:"operands"`
+		}
+		if err := strictUnmarshal(params, &named); err != nil {
+			return nil, errors.New("params must be {\"operands\": [integers]}")
+		}
+		return named.Operands, nil
+	}
+	if err := json.Unmarshal(params, &operands); err != nil {
+		return nil, errors.New("params must be an array of integers")
+	}
+	return operands, nil
+}
+
+// evalParams accepts ["expr"] or {"expression": "expr"}.
+func evalParams(params json.RawMessage) (string, error) {
+	if len(params) > 0 && params[0] == '{' {
+		var named struct {
+			Expression string `json:"expression"`
+		}
+		if err := strictUnmarshal(params, &named); err == nil && named.Expression != "" {
+			return named.Expression, nil
+		}
+	} else {
+		var positional []string
+		if err := json.Unmarshal(params, &positional); err == nil && len(positional) == 1 {
+			return positional[0], nil
+		}
+	}
+	return "", errors.New("params must be [expression] or {\"expression\": expression}")
+}
+
+func strictUnmarshal(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(v)
+}
+
+// toError maps a calc error to a JSON-RPC error object.
+func toError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	e := &Error{Message: err.Error()}
+
+	var se *calc.SyntaxError
+	var ude *calc.UndefinedError
+	var uoe *calc.UnknownOperationError
+	var ae *calc.ArityError
+	var ce *calc.CallError
+	switch {
+	case errors.As(err, &ce):
+		// An unknown function or wrong arity inside an expression is an
+		// expression error, not a problem with the RPC method itself.
+		e.Code, e.Data = CodeExpressionError, &ErrorData{Position: ce.Pos}
+	case errors.As(err, &se):
+		e.Code, e.Data = CodeExpressionError, &ErrorData{Position: se.Pos}
+	case errors.As(err, &ude):
+		e.Code, e.Data = CodeExpressionError, &ErrorData{Position: ude.Pos}
+	case errors.As(err, &uoe):
+		e.Code = CodeMethodNotFound
+	case errors.As(err, &ae):
+		e.Code = CodeInvalidParams
+	case errors.Is(err, calc.ErrDivisionByZero):
+		e.Code = CodeDivisionByZero
+	case errors.Is(err, calc.ErrOverflow):
+		e.Code = CodeOverflow
+	case errors.Is(err, calc.ErrDomain):
+		e.Code = CodeDomain
+	default:
+		e.Code = CodeCalculation
+	}
+	return e
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) *Response {
+	return &Response{JSONRPC: Version, Error: &Error{Code: code, Message: msg}, ID: id}
+}
+
+// mustMarshal encodes values that are always representable as JSON.
+func mustMarshal(v interface{}) []byte {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
@@ -0,0 +1,182 @@
+package calc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// ErrSessionNotFound is returned for an unknown, closed or expired session ID.
+var ErrSessionNotFound = Error("session not found")
+
+// SessionManager holds isolated calculator sessions keyed by ID, so one
+// process can serve many users. Sessions idle for longer than the TTL
+// expire. A SessionManager is safe for concurrent use; each session is
+// locked while a caller is using it.
+type SessionManager struct {
+	calc *Calculator
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]*managedSession
+}
+
+type managedSession struct {
+	mu       sync.Mutex // serialises use of session
+	session  *Session
+	lastUsed time.Time // guarded by SessionManager.mu
+	inUse    int       // guarded by SessionManager.mu
+}
+
+// NewSessionManager creates a manager whose sessions run on c and expire
+// after ttl without use. A ttl of zero or less disables expiry.
+func NewSessionManager(c *Calculator, ttl time.Duration) *SessionManager {
+	return NewSessionManagerWithClock(c, ttl, time.Now)
+}
+
+// NewSessionManagerWithClock is like NewSessionManager but reads the time
+// from now, which lets tests control expiry.
+func NewSessionManagerWithClock(c *Calculator, ttl time.Duration, now func() time.Time) *SessionManager {
+	return &SessionManager{
+		calc:     c,
+		ttl:      ttl,
+		now:      now,
+		sessions: make(map[string]*managedSession),
+	}
+}
+
+// Create starts a new session and returns its ID.
+func (m *SessionManager) Create() (string, error) {
+	id, err := newSessionID()
+	if err != nil {
+		return "", err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sessions[id] = &managedSession{session: m.calc.NewSession(), lastUsed: m.now()}
+	return id, nil
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Do runs fn with exclusive access to the session and marks it as used.
+// The session does not expire while fn runs.
+func (m *SessionManager) Do(id string, fn func(*Session) error) error {
+	ms, err := m.acquire(id)
+	if err != nil {
+		return err
+	}
+	defer m.release(ms)
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	return fn(ms.session)
+}
+
+// Apply runs the named operation in the session.
+func (m *SessionManager) Apply(id, name string, operands ...int) (int, error) {
+	var result int
+	err := m.Do(id, func(s *Session) error {
+		var err error
+		result, err = s.Apply(name, operands...)
+		return err
+	})
+	return result, err
+}
+
+func (m *SessionManager) acquire(id string) (*managedSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	ms, ok := m.sessions[id]
+	if !ok {
+		return nil, ErrSessionNotFound
+	}
+	now := m.now()
+	if m.expired(ms, now) {
+		delete(m.sessions, id)
+		return nil, ErrSessionNotFound
+	}
+	ms.lastUsed = now
+	ms.inUse++
+	return ms, nil
+}
+
+func (m *SessionManager) release(ms *managedSession) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ms.lastUsed = m.now()
+	ms.inUse--
+}
+
+func (m *SessionManager) expired(ms *managedSession, now time.Time) bool {
+	return m.ttl > 0 && ms.inUse == 0 && now.Sub(ms.lastUsed) >= m.ttl
+}
+
+// Close removes the session. Callers already inside Do finish normally.
+func (m *SessionManager) Close(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		return ErrSessionNotFound
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+// Len returns the number of sessions held, including expired ones that
+// have not been swept yet.
+func (m *SessionManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.sessions)
+}
+
+// Sweep removes expired sessions and returns how many were removed.
+func (m *SessionManager) Sweep() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	removed := 0
+	for id, ms := range m.sessions {
+		if m.expired(ms, now) {
+			delete(m.sessions, id)
+			removed++
+		}
+	}
+	return removed
+}
+
+// RunJanitor calls Sweep every interval until ctx is cancelled. It blocks,
+// so start it in its own goroutine. An interval of zero or less means the
+// TTL; if expiry is disabled too, there is nothing to sweep and RunJanitor
+// returns at once.
+func (m *SessionManager) RunJanitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = m.ttl
+	}
+	if interval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Sweep()
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package calc
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// ErrDomain is returned when an operand is outside an operation's domain,
+// such as a negative exponent or the factorial of a negative number.
+var ErrDomain = Error("operand out of domain")
+
+// MathPack returns the built-in extra operations: power, gcd, lcm, modpow,
+// factorial and isqrt. DefaultRegistry includes them.
+func MathPack() []Operation {
+	return []Operation{
+		Binary("power", Power, ErrDomain, ErrOverflow),
+		Binary("gcd", GCD, ErrOverflow),
+		Binary("lcm", LCM, ErrOverflow),
+		{
+			Name:  "modpow",
+			Arity: 3,
+			Apply: func(operands ...int) (int, error) {
+				return ModPow(operands[0], operands[1], operands[2])
+			},
+			Errors: []error{ErrDomain, ErrDivisionByZero},
+		},
+		Unary("factorial", Factorial, ErrDomain, ErrOverflow),
+		Unary("isqrt", ISqrt, ErrDomain),
+	}
+}
+
+// Power returns base raised to exp. It returns ErrDomain for a negative
+// exponent and an *OverflowError if the result does not fit in an int.
+func Power(base, exp int) (int, error) {
+	if exp < 0 {
+		return 0, ErrDomain
+	}
+	result, b := 1, base
+	for e := exp; e > 0; e >>= 1 {
+		var err error
+		if e&1 == 1 {
+			if result, err = MultiplyChecked(result, b); err != nil {
+				return 0, &OverflowError{Op: "power", A: base, B: exp}
+			}
+		}
+		if e > 1 {
+			if b, err = MultiplyChecked(b, b); err != nil {
+				return 0, &OverflowError{Op: "power", A: base, B: exp}
+			}
+		}
+	}
+	return result, nil
+}
+
+// GCD returns the greatest common divisor of a and b, which is never
+// negative. GCD(0, 0) is 0. It returns an *OverflowError when the result
+// is -math.MinInt.
+func GCD(a, b int) (int, error) {
+	g := gcd(absUint(a), absUint(b))
+	if g > math.MaxInt {
+		return 0, &OverflowError{Op: "gcd", A: a, B: b}
+	}
+	return int(g), nil
+}
+
+// LCM returns the least common multiple of a and b, which is never
+// negative. LCM is 0 if either operand is 0.
+func LCM(a, b int) (int, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	ua, ub := absUint(a), absUint(b)
+	hi, lo := bits.Mul(ua/gcd(ua, ub), ub)
+	if hi != 0 || lo > math.MaxInt {
+		return 0, &OverflowError{Op: "lcm", A: a, B: b}
+	}
+	return int(lo), nil
+}
+
+// ModPow returns base^exp mod m, in the range [0, m). It returns
+// ErrDivisionByZero if m is 0 and ErrDomain for a negative exponent or
+// modulus. Intermediate products never overflow.
+func ModPow(base, exp, m int) (int, error) {
+	switch {
+	case m == 0:
+		return 0, ErrDivisionByZero
+	case m < 0 || exp < 0:
+		return 0, ErrDomain
+	}
+	b, _ := Mod(base, m, Euclidean)
+	return int(powMod(uint64(b), uint64(exp), uint64(m))), nil
+}
+
+// mulMod returns a*b mod m using a 128-bit intermediate product.
+// It requires a, b < m.
+func mulMod(a, b, m uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	return bits.Rem64(hi, lo, m)
+}
+
+// powMod returns base^exp mod m. It requires base < m.
+func powMod(base, exp, m uint64) uint64 {
+	result := 1 % m
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			result = mulMod(result, base, m)
+		}
+		base = mulMod(base, base, m)
+	}
+	return result
+}
+
+// Factorial returns n!. It returns ErrDomain for negative n and an error
+// wrapping ErrOverflow once the result no longer fits in an int.
+func Factorial(n int) (int, error) {
+	if n < 0 {
+		return 0, ErrDomain
+	}
+	result := 1
+	for i := 2; i <= n; i++ {
+		var err error
+		if result, err = MultiplyChecked(result, i); err != nil {
+			return 0, fmt.Errorf("factorial(%d): %w", n, ErrOverflow)
+		}
+	}
+	return result, nil
+}
+
+// ISqrt returns the integer square root of n, the largest r with r*r <= n.
+// It returns ErrDomain for negative n.
+func ISqrt(n int) (int, error) {
+	if n < 0 {
+		return 0, ErrDomain
+	}
+	// The float estimate can be off by one either way for large n.
+	r := int(math.Sqrt(float64(n)))
+	for r > 0 && r > n/r {
+		r--
+	}
+	for r+1 <= n/(r+1) {
+		r++
+	}
+	return r, nil
+}
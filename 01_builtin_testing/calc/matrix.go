@@ -0,0 +1,209 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// DimensionError reports operands whose lengths or shapes do not fit an
+// operation, e.g. adding vectors of different lengths.
+type DimensionError struct {
+	Op        string
+	Want, Got int
+}
+
+func (e *DimensionError) Error() string {
+	return fmt.Sprintf("%s: dimension mismatch: want %d, got %d", e.Op, e.Want, e.Got)
+}
+
+// AddVectors returns the element-wise sum of a and b.
+func AddVectors(a, b []int) ([]int, error) {
+	return elementWise("add", a, b, SumChecked)
+}
+
+// SubtractVectors returns the element-wise difference a - b.
+func SubtractVectors(a, b []int) ([]int, error) {
+	return elementWise("subtract", a, b, SubtractChecked)
+}
+
+// MultiplyVectors returns the element-wise (Hadamard) product of a and b.
+func MultiplyVectors(a, b []int) ([]int, error) {
+	return elementWise("multiply", a, b, MultiplyChecked)
+}
+
+func elementWise(op string, a, b []int, fn func(x, y int) (int, error)) ([]int, error) {
+	if len(a) != len(b) {
+		return nil, &DimensionError{Op: op, Want: len(a), Got: len(b)}
+	}
+	result := make([]int, len(a))
+	for i := range a {
+		var err error
+		if result[i], err = fn(a[i], b[i]); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Dot returns the dot product of a and b. Intermediate sums may exceed an
+// int; only a final result that does not fit is reported as overflow.
+func Dot(a, b []int) (int, error) {
+	if len(a) != len(b) {
+		return 0, &DimensionError{Op: "dot", Want: len(a), Got: len(b)}
+	}
+	return dot(func(i int) (int, int) { return a[i], b[i] }, len(a))
+}
+
+// dot sums the products of the n pairs returned by pair in arbitrary
+// precision.
+func dot(pair func(i int) (int, int), n int) (int, error) {
+	var sum, term big.Int
+	for i := 0; i < n; i++ {
+		x, y := pair(i)
+		sum.Add(&sum, term.Mul(big.NewInt(int64(x)), big.NewInt(int64(y))))
+	}
+	return fitInt("dot", &sum)
+}
+
+// fitInt converts v to an int or reports overflow.
+func fitInt(op string, v *big.Int) (int, error) {
+	if !v.IsInt64() || int64(int(v.Int64())) != v.Int64() {
+		return 0, fmt.Errorf("%s: %w", op, ErrOverflow)
+	}
+	return int(v.Int64()), nil
+}
+
+// Matrix is a row-major integer matrix. Every row must have the same
+// length; functions given a ragged matrix return a *DimensionError.
+type Matrix [][]int
+
+// Rows returns the number of rows.
+func (m Matrix) Rows() int {
+	return len(m)
+}
+
+// Cols returns the number of columns, taken from the first row.
+func (m Matrix) Cols() int {
+	if len(m) == 0 {
+		return 0
+	}
+	return len(m[0])
+}
+
+// validate checks that every row of m has the same length.
+func (m Matrix) validate(op string) error {
+	for _, row := range m {
+		if len(row) != m.Cols() {
+			return &DimensionError{Op: op, Want: m.Cols(), Got: len(row)}
+		}
+	}
+	return nil
+}
+
+// NewMatrix returns a rows x cols zero matrix.
+func NewMatrix(rows, cols int) Matrix {
+	m := make(Matrix, rows)
+	for i := range m {
+		m[i] = make([]int, cols)
+	}
+	return m
+}
+
+// Transpose returns the transpose of m.
+func Transpose(m Matrix) (Matrix, error) {
+	if err := m.validate("transpose"); err != nil {
+		return nil, err
+	}
+	t := NewMatrix(m.Cols(), m.Rows())
+	for i, row := range m {
+		for j, v := range row {
+			t[j][i] = v
+		}
+	}
+	return t, nil
+}
+
+// MatMul returns the matrix product a × b. The number of columns of a must
+// equal the number of rows of b.
+func MatMul(a, b Matrix) (Matrix, error) {
+	if err := a.validate("matmul"); err != nil {
+		return nil, err
+	}
+	if err := b.validate("matmul"); err != nil {
+		return nil, err
+	}
+	if a.Cols() != b.Rows() {
+		return nil, &DimensionError{Op: "matmul", Want: a.Cols(), Got: b.Rows()}
+	}
+
+	product := NewMatrix(a.Rows(), b.Cols())
+	for i := range product {
+		for j := range product[i] {
+			v, err := dot(func(k int) (int, int) { return a[i][k], b[k][j] }, a.Cols())
+			if err != nil {
+				return nil, fmt.Errorf("matmul: %w", ErrOverflow)
+			}
+			product[i][j] = v
+		}
+	}
+	return product, nil
+}
+
+// Determinant returns the determinant of the square matrix m, using
+// fraction-free Bareiss elimination in arbitrary precision. The
+// determinant of a 0x0 matrix is 1.
+func Determinant(m Matrix) (int, error) {
+	if err := m.validate("determinant"); err != nil {
+		return 0, err
+	}
+	n := m.Rows()
+	if m.Cols() != n {
+		return 0, &DimensionError{Op: "determinant", Want: n, Got: m.Cols()}
+	}
+
+	a := make([][]*big.Int, n)
+	for i, row := range m {
+		a[i] = make([]*big.Int, n)
+		for j, v := range row {
+			a[i][j] = big.NewInt(int64(v))
+		}
+	}
+
+	sign, prev := 1, big.NewInt(1)
+	var t big.Int
+	for k := 0; k < n-1; k++ {
+		if a[k][k].Sign() == 0 {
+			swap := -1
+			for i := k + 1; i < n; i++ {
+				if a[i][k].Sign() != 0 {
+					swap = i
+					break
+				}
+			}
+			if swap < 0 {
+				return 0, nil
+			}
+			a[k], a[swap] = a[swap], a[k]
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			for j := k + 1; j < n; j++ {
+				// a[i][j] = (a[i][j]*a[k][k] - a[i][k]*a[k][j]) / prev,
+				// which Bareiss guarantees is exact.
+				a[i][j].Mul(a[i][j], a[k][k])
+				a[i][j].Sub(a[i][j], t.Mul(a[i][k], a[k][j]))
+				a[i][j].Quo(a[i][j], prev)
+			}
+		}
+		prev = a[k][k]
+	}
+
+	if n == 0 {
+		return 1, nil
+	}
+	det := new(big.Int).Set(a[n-1][n-1])
+	if sign < 0 {
+		det.Neg(det)
+	}
+	return fitInt("determinant", det)
+}
@@ -0,0 +1,78 @@
+package calc
+
+// ErrErrorDisplayed is returned by MemoryAdd and MemorySubtract when the
+// session's current value is an error, mirroring a physical calculator that
+// ignores M+ and M- until the error is cleared.
+var ErrErrorDisplayed = Error("cannot use memory while an error is displayed")
+
+// Memory key names accepted by Session.PressMemory.
+const (
+	KeyMemoryAdd      = "M+"
+	KeyMemorySubtract = "M-"
+	KeyMemoryRecall   = "MR"
+	KeyMemoryClear    = "MC"
+)
+
+// Memory returns the value held in the memory register.
+func (s *Session) Memory() int {
+	return s.memory
+}
+
+// MemoryAdd adds the current value to memory (M+).
+// The register is unchanged if the current value is an error or the sum
+// overflows.
+func (s *Session) MemoryAdd() error {
+	return s.updateMemory(SumChecked)
+}
+
+// MemorySubtract subtracts the current value from memory (M-).
+// The register is unchanged if the current value is an error or the
+// difference overflows.
+func (s *Session) MemorySubtract() error {
+	return s.updateMemory(SubtractChecked)
+}
+
+func (s *Session) updateMemory(op func(a, b int) (int, error)) error {
+	value, err := s.Value()
+	if err != nil {
+		return ErrErrorDisplayed
+	}
+	memory, err := op(s.memory, value)
+	if err != nil {
+		return err
+	}
+	s.memory = memory
+	return nil
+}
+
+// MemoryRecall makes the memory register the current value (MR). It is
+// recorded in the history as a "recall" entry, so it replaces a displayed
+// error and can be undone like any other operation.
+func (s *Session) MemoryRecall() int {
+	s.record(Entry{Operation: "recall", Operands: []int{}, Result: s.memory})
+	return s.memory
+}
+
+// MemoryClear resets the memory register to zero (MC).
+func (s *Session) MemoryClear() {
+	s.memory = 0
+}
+
+// PressMemory dispatches a memory key by name: "M+", "M-", "MR" or "MC".
+// It returns an *UnknownOperationError for any other key.
+func (s *Session) PressMemory(key string) error {
+	switch key {
+	case KeyMemoryAdd:
+		return s.MemoryAdd()
+	case KeyMemorySubtract:
+		return s.MemorySubtract()
+	case KeyMemoryRecall:
+		s.MemoryRecall()
+		return nil
+	case KeyMemoryClear:
+		s.MemoryClear()
+		return nil
+	default:
+		return &UnknownOperationError{Name: key}
+	}
+}
@@ -0,0 +1,174 @@
+// Package numeric provides the calc operations for every Go number type.
+// It is the base of calc: the int functions there are instantiations of
+// the ones here, and the error values are shared.
+//
+// Each instantiation keeps the behaviour of its type: integers wrap on
+// overflow and report ErrDivisionByZero, while floats follow IEEE 754 and
+// divide by zero to ±Inf or NaN. The Checked variants additionally report
+// ErrOverflow when an integer result wraps or a float result becomes
+// infinite from finite operands.
+package numeric
+
+import (
+	"fmt"
+	"math"
+)
+
+// Signed is the set of signed integer types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is the set of unsigned integer types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer is the set of integer types.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float is the set of floating-point types.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number is the set of types the operations accept.
+type Number interface {
+	Integer | Float
+}
+
+// Error is a simple error type.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// The errors are shared with calc, which re-exports them, so callers can
+// test for them with errors.Is regardless of which package produced them.
+var (
+	// ErrDivisionByZero is returned when dividing an integer by zero.
+	ErrDivisionByZero = Error("cannot divide by zero")
+	// ErrOverflow is the sentinel wrapped by every OverflowError.
+	ErrOverflow = Error("integer overflow")
+)
+
+// OverflowError reports an operation whose result does not fit in T.
+type OverflowError[T Number] struct {
+	Op   string // operation name, e.g. "add"
+	A, B T      // operands
+}
+
+func (e *OverflowError[T]) Error() string {
+	return fmt.Sprintf("%s(%v, %v): %s", e.Op, e.A, e.B, ErrOverflow)
+}
+
+// Unwrap allows errors.Is(err, ErrOverflow).
+func (e *OverflowError[T]) Unwrap() error {
+	return ErrOverflow
+}
+
+// Sum returns a + b.
+func Sum[T Number](a, b T) T {
+	return a + b
+}
+
+// Subtract returns a - b.
+func Subtract[T Number](a, b T) T {
+	return a - b
+}
+
+// Multiply returns a * b.
+func Multiply[T Number](a, b T) T {
+	return a * b
+}
+
+// Divide returns a / b. Integer division truncates towards zero and returns
+// ErrDivisionByZero for b == 0; float division never fails.
+func Divide[T Number](a, b T) (T, error) {
+	if b == 0 && !isFloat[T]() {
+		return 0, ErrDivisionByZero
+	}
+	return a / b, nil
+}
+
+// SumChecked returns a + b, or an *OverflowError if the result wraps.
+func SumChecked[T Number](a, b T) (T, error) {
+	sum := a + b
+	if isFloat[T]() {
+		return sum, checkInf("add", a, b, sum)
+	}
+	if (b > 0 && sum < a) || (b < 0 && sum > a) {
+		return 0, overflow("add", a, b)
+	}
+	return sum, nil
+}
+
+// SubtractChecked returns a - b, or an *OverflowError if the result wraps.
+func SubtractChecked[T Number](a, b T) (T, error) {
+	diff := a - b
+	if isFloat[T]() {
+		return diff, checkInf("subtract", a, b, diff)
+	}
+	if (b > 0 && diff > a) || (b < 0 && diff < a) {
+		return 0, overflow("subtract", a, b)
+	}
+	return diff, nil
+}
+
+// MultiplyChecked returns a * b, or an *OverflowError if the result wraps.
+func MultiplyChecked[T Number](a, b T) (T, error) {
+	product := a * b
+	if isFloat[T]() {
+		return product, checkInf("multiply", a, b, product)
+	}
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+	// The division check misses MinValue * -1, which wraps to MinValue;
+	// the sign check catches it.
+	if product/b != a || ((a < 0) != (b < 0)) != (product < 0) {
+		return 0, overflow("multiply", a, b)
+	}
+	return product, nil
+}
+
+// DivideChecked is Divide plus overflow reporting for MinValue / -1.
+func DivideChecked[T Number](a, b T) (T, error) {
+	quotient, err := Divide(a, b)
+	if err != nil {
+		return 0, err
+	}
+	if isFloat[T]() {
+		return quotient, checkInf("divide", a, b, quotient)
+	}
+	if a < 0 && b < 0 && quotient < 0 {
+		return 0, overflow("divide", a, b)
+	}
+	return quotient, nil
+}
+
+// isFloat reports whether T is a floating-point type.
+func isFloat[T Number]() bool {
+	var one T = 1
+	return one/2 != 0
+}
+
+// checkInf reports overflow when finite operands produce an infinite result.
+// Division of a finite value by zero is not overflow: it is the IEEE
+// definition of the operation.
+func checkInf[T Number](op string, a, b, result T) error {
+	if op == "divide" && b == 0 {
+		return nil
+	}
+	if math.IsInf(float64(result), 0) && !math.IsInf(float64(a), 0) && !math.IsInf(float64(b), 0) {
+		return overflow(op, a, b)
+	}
+	return nil
+}
+
+func overflow[T Number](op string, a, b T) error {
+	return &OverflowError[T]{Op: op, A: a, B: b}
+}
@@ -0,0 +1,139 @@
+package calc
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// millerRabinBases are enough witnesses to make Miller-Rabin deterministic
+// for every 64-bit integer.
+var millerRabinBases = []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29, 31, 37}
+
+// IsPrime reports whether n is prime. It uses a deterministic Miller-Rabin
+// test, so the answer is exact for every int.
+func IsPrime(n int) bool {
+	return n > 1 && isPrime(uint64(n))
+}
+
+func isPrime(n uint64) bool {
+	if n < 2 {
+		return false
+	}
+	for _, p := range millerRabinBases {
+		if n%p == 0 {
+			return n == p
+		}
+	}
+
+	d, s := n-1, 0
+	for d%2 == 0 {
+		d /= 2
+		s++
+	}
+witnesses:
+	for _, a := range millerRabinBases {
+		x := powMod(a, d, n)
+		if x == 1 || x == n-1 {
+			continue
+		}
+		for i := 1; i < s; i++ {
+			x = mulMod(x, x, n)
+			if x == n-1 {
+				continue witnesses
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Factorize returns the prime factors of n in ascending order, repeated
+// according to multiplicity, so their product is n. Factorize(1) is empty.
+// It returns ErrDomain for n < 1.
+func Factorize(n int) ([]int, error) {
+	if n < 1 {
+		return nil, ErrDomain
+	}
+	factors := []int{}
+	u := uint64(n)
+	for p := uint64(2); p < 1000 && p*p <= u; p++ {
+		for u%p == 0 {
+			factors = append(factors, int(p))
+			u /= p
+		}
+	}
+	factors = appendFactors(factors, u)
+	sort.Ints(factors)
+	return factors, nil
+}
+
+// appendFactors appends the prime factors of n, which has no factors
+// below 1000 unless it is itself small.
+func appendFactors(factors []int, n uint64) []int {
+	switch {
+	case n == 1:
+		return factors
+	case isPrime(n):
+		return append(factors, int(n))
+	}
+	d := pollardRho(n)
+	return appendFactors(appendFactors(factors, d), n/d)
+}
+
+// pollardRho returns a non-trivial divisor of the odd composite n.
+func pollardRho(n uint64) uint64 {
+	for c := uint64(1); ; c++ {
+		f := func(x uint64) uint64 { return (mulMod(x, x, n) + c) % n }
+		x, y, d := uint64(2), uint64(2), uint64(1)
+		for d == 1 {
+			x, y = f(x), f(f(y))
+			diff := x - y
+			if x < y {
+				diff = y - x
+			}
+			d = uint64(gcd(uint(diff), uint(n)))
+		}
+		if d != n {
+			return d
+		}
+	}
+}
+
+// PopCount returns the number of one bits in the two's complement
+// representation of n.
+func PopCount(n int) int {
+	return bits.OnesCount64(uint64(n))
+}
+
+// ShiftLeft returns n << s, which is n * 2^s. It returns ErrDomain for a
+// negative shift and an *OverflowError if the result does not fit.
+func ShiftLeft(n, s int) (int, error) {
+	if s < 0 {
+		return 0, ErrDomain
+	}
+	// Shifting back recovers n exactly when no significant bit, including
+	// the sign, was shifted out.
+	if r := n << s; r>>s == n {
+		return r, nil
+	}
+	return 0, &OverflowError{Op: "shl", A: n, B: s}
+}
+
+// ShiftRight returns n >> s, an arithmetic shift that rounds towards
+// negative infinity. It returns ErrDomain for a negative shift.
+func ShiftRight(n, s int) (int, error) {
+	if s < 0 {
+		return 0, ErrDomain
+	}
+	return n >> s, nil
+}
+
+// RotateLeft rotates the bits of n left by k; a negative k rotates right.
+func RotateLeft(n, k int) int {
+	return int(bits.RotateLeft(uint(n), k))
+}
+
+// RotateRight rotates the bits of n right by k; a negative k rotates left.
+func RotateRight(n, k int) int {
+	return RotateLeft(n, -k)
+}
@@ -0,0 +1,254 @@
+package calc
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Rational is an exact fraction of two ints, kept in lowest terms with a
+// positive denominator, so equal values always have equal fields and can be
+// compared with ==. The zero value is 0.
+//
+// Operations report *OverflowError when a normalised numerator or
+// denominator does not fit in an int.
+type Rational struct {
+	num int
+	dm1 int // denominator minus one, so the zero value is 0/1
+}
+
+// NewRational returns num/den in lowest terms.
+// It returns ErrDivisionByZero if den is zero.
+func NewRational(num, den int) (Rational, error) {
+	if den == 0 {
+		return Rational{}, ErrDivisionByZero
+	}
+	// The gcd of MinInt with itself or with 0 does not fit in an int.
+	if num == 0 {
+		return Rational{}, nil
+	}
+	if num == den {
+		return Rational{num: 1}, nil
+	}
+
+	g := int(gcd(absUint(num), absUint(den)))
+	if g > 1 {
+		num, den = num/g, den/g
+	}
+	if den < 0 {
+		// After reduction only num or den equal to MinInt with the other
+		// ±1 can still overflow on negation.
+		n, err := SubtractChecked(0, num)
+		if err != nil {
+			return Rational{}, &OverflowError{Op: "normalise", A: num, B: den}
+		}
+		d, err := SubtractChecked(0, den)
+		if err != nil {
+			return Rational{}, &OverflowError{Op: "normalise", A: num, B: den}
+		}
+		num, den = n, d
+	}
+	return Rational{num: num, dm1: den - 1}, nil
+}
+
+// RationalFromInt returns n/1.
+func RationalFromInt(n int) Rational {
+	return Rational{num: n}
+}
+
+// ParseRational parses "num/den" or a plain integer such as "-7".
+func ParseRational(s string) (Rational, error) {
+	numText, denText, isFraction := strings.Cut(s, "/")
+	num, err := strconv.Atoi(strings.TrimSpace(numText))
+	if err != nil {
+		return Rational{}, fmt.Errorf("invalid rational %q", s)
+	}
+	if !isFraction {
+		return RationalFromInt(num), nil
+	}
+	den, err := strconv.Atoi(strings.TrimSpace(denText))
+	if err != nil {
+		return Rational{}, fmt.Errorf("invalid rational %q", s)
+	}
+	return NewRational(num, den)
+}
+
+// Num returns the numerator.
+func (r Rational) Num() int {
+	return r.num
+}
+
+// Den returns the denominator, which is always positive.
+func (r Rational) Den() int {
+	return r.dm1 + 1
+}
+
+// String returns "num/den", or just "num" for whole numbers.
+func (r Rational) String() string {
+	if r.Den() == 1 {
+		return strconv.Itoa(r.num)
+	}
+	return strconv.Itoa(r.num) + "/" + strconv.Itoa(r.Den())
+}
+
+// Float64 returns the nearest float64 value.
+func (r Rational) Float64() float64 {
+	return float64(r.num) / float64(r.Den())
+}
+
+// Sign returns -1, 0 or +1.
+func (r Rational) Sign() int {
+	switch {
+	case r.num < 0:
+		return -1
+	case r.num > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsPositive checks if r is greater than zero.
+func (r Rational) IsPositive() bool {
+	return r.num > 0
+}
+
+// Cmp returns -1, 0 or +1 as r is less than, equal to or greater than other.
+func (r Rational) Cmp(other Rational) int {
+	// Cross-multiplied values can exceed int, so compare them as big.Ints.
+	left := new(big.Int).Mul(big.NewInt(int64(r.num)), big.NewInt(int64(other.Den())))
+	right := new(big.Int).Mul(big.NewInt(int64(other.num)), big.NewInt(int64(r.Den())))
+	return left.Cmp(right)
+}
+
+// Add returns r + other.
+func (r Rational) Add(other Rational) (Rational, error) {
+	return r.addScaled(other, 1)
+}
+
+// Subtract returns r - other.
+func (r Rational) Subtract(other Rational) (Rational, error) {
+	return r.addScaled(other, -1)
+}
+
+// addScaled returns r + sign*other over the least common denominator.
+func (r Rational) addScaled(other Rational, sign int) (Rational, error) {
+	b, d := r.Den(), other.Den()
+	g := int(gcd(uint(b), uint(d)))
+
+	left, err := MultiplyChecked(r.num, d/g)
+	if err != nil {
+		return Rational{}, err
+	}
+	right, err := MultiplyChecked(other.num, b/g)
+	if err != nil {
+		return Rational{}, err
+	}
+	var num int
+	if sign < 0 {
+		num, err = SubtractChecked(left, right)
+	} else {
+		num, err = SumChecked(left, right)
+	}
+	if err != nil {
+		return Rational{}, err
+	}
+	den, err := MultiplyChecked(b/g, d)
+	if err != nil {
+		return Rational{}, err
+	}
+	return NewRational(num, den)
+}
+
+// Multiply returns r × other.
+func (r Rational) Multiply(other Rational) (Rational, error) {
+	// Cross-cancel first so intermediate products stay as small as possible.
+	a, b := r.num, r.Den()
+	c, d := other.num, other.Den()
+	if g := int(gcd(absUint(a), uint(d))); g > 1 {
+		a, d = a/g, d/g
+	}
+	if g := int(gcd(absUint(c), uint(b))); g > 1 {
+		c, b = c/g, b/g
+	}
+
+	num, err := MultiplyChecked(a, c)
+	if err != nil {
+		return Rational{}, err
+	}
+	den, err := MultiplyChecked(b, d)
+	if err != nil {
+		return Rational{}, err
+	}
+	return NewRational(num, den)
+}
+
+// Divide returns r / other, or ErrDivisionByZero if other is zero.
+func (r Rational) Divide(other Rational) (Rational, error) {
+	if other.num == 0 {
+		return Rational{}, ErrDivisionByZero
+	}
+	inverse, err := NewRational(other.Den(), other.num)
+	if err != nil {
+		return Rational{}, err
+	}
+	return r.Multiply(inverse)
+}
+
+// SumRationals returns the sum of a slice of rationals.
+func SumRationals(values []Rational) (Rational, error) {
+	var total Rational
+	for _, v := range values {
+		var err error
+		if total, err = total.Add(v); err != nil {
+			return Rational{}, err
+		}
+	}
+	return total, nil
+}
+
+// Rat is the exact-fraction backend: Divide never truncates.
+var Rat Operations[Rational] = ratOps{}
+
+type ratOps struct{}
+
+func (ratOps) Parse(s string) (Rational, error) {
+	return ParseRational(s)
+}
+
+func (ratOps) Format(v Rational) string {
+	return v.String()
+}
+
+func (ratOps) Sum(a, b Rational) (Rational, error) {
+	return a.Add(b)
+}
+
+func (ratOps) Subtract(a, b Rational) (Rational, error) {
+	return a.Subtract(b)
+}
+
+func (ratOps) Multiply(a, b Rational) (Rational, error) {
+	return a.Multiply(b)
+}
+
+func (ratOps) Divide(a, b Rational) (Rational, error) {
+	return a.Divide(b)
+}
+
+// absUint returns |n| without overflowing for math.MinInt.
+func absUint(n int) uint {
+	if n < 0 {
+		return uint(-(n + 1)) + 1
+	}
+	return uint(n)
+}
+
+// gcd returns the greatest common divisor of a and b, with gcd(0, 0) == 0.
+func gcd(a, b uint) uint {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
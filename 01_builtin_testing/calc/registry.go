@@ -0,0 +1,192 @@
+package calc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Operation is a named calculator operation that can be looked up and
+// applied at runtime, e.g. when a BDD step says "I press divide".
+type Operation struct {
+	Name  string
+	Arity int // number of operands Apply expects
+	Apply func(operands ...int) (int, error)
+
+	// Errors is the operation's error contract: the errors it may return,
+	// matched with errors.Is. Registry.Apply wraps any other error in a
+	// *ContractError, so an operation that declares none must not fail.
+	Errors []error
+}
+
+// Unary adapts a one-operand function to an Operation.
+func Unary(name string, fn func(a int) (int, error), errs ...error) Operation {
+	return Operation{
+		Name:  name,
+		Arity: 1,
+		Apply: func(operands ...int) (int, error) {
+			return fn(operands[0])
+		},
+		Errors: errs,
+	}
+}
+
+// Binary adapts a two-operand function to an Operation.
+func Binary(name string, fn func(a, b int) (int, error), errs ...error) Operation {
+	return Operation{
+		Name:  name,
+		Arity: 2,
+		Apply: func(operands ...int) (int, error) {
+			return fn(operands[0], operands[1])
+		},
+		Errors: errs,
+	}
+}
+
+// declares reports whether err is part of the operation's error contract.
+func (op Operation) declares(err error) bool {
+	for _, target := range op.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnknownOperationError reports a name that is not registered.
+type UnknownOperationError struct {
+	Name string
+}
+
+func (e *UnknownOperationError) Error() string {
+	return "unknown operation: " + e.Name
+}
+
+// ArityError reports an operation applied to the wrong number of operands.
+type ArityError struct {
+	Name      string
+	Want, Got int
+}
+
+func (e *ArityError) Error() string {
+	return fmt.Sprintf("%s takes %d numbers, got %d", e.Name, e.Want, e.Got)
+}
+
+// ContractError reports an operation that returned an error it did not
+// declare in Operation.Errors. It unwraps to the original error.
+type ContractError struct {
+	Name string
+	Err  error
+}
+
+func (e *ContractError) Error() string {
+	return fmt.Sprintf("%s: undeclared error: %v", e.Name, e.Err)
+}
+
+func (e *ContractError) Unwrap() error {
+	return e.Err
+}
+
+// Registry maps operation names to operations. It is safe for concurrent use.
+type Registry struct {
+	mu  sync.RWMutex
+	ops map[string]Operation
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{ops: make(map[string]Operation)}
+}
+
+// DefaultRegistry holds the standard operations (add, subtract, multiply,
+// divide and mod) and the MathPack. Calculators created with NewCalculator,
+// Eval and the calc command use it, so operations registered here are
+// available everywhere.
+var DefaultRegistry = newDefaultRegistry()
+
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	if err := r.RegisterAll(StandardOperations()...); err != nil {
+		panic(err)
+	}
+	if err := r.RegisterAll(MathPack()...); err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// StandardOperations returns the four arithmetic operations and mod, all
+// overflow-checked. Mod uses Truncated semantics.
+func StandardOperations() []Operation {
+	return []Operation{
+		Binary("add", SumChecked, ErrOverflow),
+		Binary("subtract", SubtractChecked, ErrOverflow),
+		Binary("multiply", MultiplyChecked, ErrOverflow),
+		Binary("divide", DivideChecked, ErrDivisionByZero, ErrOverflow),
+		Binary("mod", func(a, b int) (int, error) { return Mod(a, b, Truncated) }, ErrDivisionByZero, ErrOverflow),
+	}
+}
+
+// Register adds op to the registry. Names must be unique.
+func (r *Registry) Register(op Operation) error {
+	if op.Name == "" || op.Apply == nil || op.Arity < 0 {
+		return fmt.Errorf("invalid operation %q", op.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.ops[op.Name]; exists {
+		return fmt.Errorf("operation %q already registered", op.Name)
+	}
+	r.ops[op.Name] = op
+	return nil
+}
+
+// RegisterAll registers each of ops, stopping at the first error.
+func (r *Registry) RegisterAll(ops ...Operation) error {
+	for _, op := range ops {
+		if err := r.Register(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Lookup returns the operation registered under name.
+func (r *Registry) Lookup(name string) (Operation, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	op, ok := r.ops[name]
+	return op, ok
+}
+
+// Names returns the registered operation names in sorted order.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.ops))
+	for name := range r.ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply runs the named operation. It returns an *UnknownOperationError or
+// *ArityError before calling the operation if the request is invalid, and
+// a *ContractError if the operation fails with an undeclared error.
+func (r *Registry) Apply(name string, operands ...int) (int, error) {
+	op, ok := r.Lookup(name)
+	if !ok {
+		return 0, &UnknownOperationError{Name: name}
+	}
+	if len(operands) != op.Arity {
+		return 0, &ArityError{Name: name, Want: op.Arity, Got: len(operands)}
+	}
+	result, err := op.Apply(operands...)
+	if err != nil && !op.declares(err) {
+		return 0, &ContractError{Name: name, Err: err}
+	}
+	return result, err
+}
@@ -0,0 +1,153 @@
+package calc
+
+import "fmt"
+
+// StackUnderflowError reports an operation that needs more values than the
+// stack holds.
+type StackUnderflowError struct {
+	Op         string
+	Need, Have int
+}
+
+func (e *StackUnderflowError) Error() string {
+	return fmt.Sprintf("%s needs %d values on the stack, have %d", e.Op, e.Need, e.Have)
+}
+
+// Stack is a last-in, first-out stack of ints. The zero value is an empty
+// stack ready to use.
+type Stack struct {
+	values []int
+}
+
+// Push puts v on top of the stack.
+func (s *Stack) Push(v int) {
+	s.values = append(s.values, v)
+}
+
+// Pop removes and returns the top value.
+func (s *Stack) Pop() (int, error) {
+	if len(s.values) == 0 {
+		return 0, &StackUnderflowError{Op: "pop", Need: 1}
+	}
+	v := s.values[len(s.values)-1]
+	s.values = s.values[:len(s.values)-1]
+	return v, nil
+}
+
+// Peek returns the top value without removing it.
+func (s *Stack) Peek() (int, error) {
+	if len(s.values) == 0 {
+		return 0, &StackUnderflowError{Op: "peek", Need: 1}
+	}
+	return s.values[len(s.values)-1], nil
+}
+
+// Len returns the number of values on the stack.
+func (s *Stack) Len() int {
+	return len(s.values)
+}
+
+// Values returns a copy of the stack contents, bottom first.
+func (s *Stack) Values() []int {
+	return append([]int(nil), s.values...)
+}
+
+// RPN is a Reverse Polish Notation calculator: numbers are pushed onto a
+// stack and operations consume their operands from the top of it, so
+// "10 2 divide" leaves 5. Failed operations leave the stack unchanged.
+// An RPN is not safe for concurrent use.
+type RPN struct {
+	calc  *Calculator
+	stack Stack
+}
+
+// NewRPN starts an RPN calculator whose operations come from c's registry.
+func (c *Calculator) NewRPN() *RPN {
+	return &RPN{calc: c}
+}
+
+// Push puts v on top of the stack.
+func (r *RPN) Push(v int) {
+	r.stack.Push(v)
+}
+
+// Pop removes and returns the top value.
+func (r *RPN) Pop() (int, error) {
+	return r.stack.Pop()
+}
+
+// Peek returns the top value, which is what a calculator would display.
+func (r *RPN) Peek() (int, error) {
+	return r.stack.Peek()
+}
+
+// Len returns the number of values on the stack.
+func (r *RPN) Len() int {
+	return r.stack.Len()
+}
+
+// Stack returns a copy of the stack contents, bottom first.
+func (r *RPN) Stack() []int {
+	return r.stack.Values()
+}
+
+// Dup pushes a copy of the top value.
+func (r *RPN) Dup() error {
+	if err := r.need("dup", 1); err != nil {
+		return err
+	}
+	r.stack.Push(r.stack.values[len(r.stack.values)-1])
+	return nil
+}
+
+// Swap exchanges the top two values.
+func (r *RPN) Swap() error {
+	if err := r.need("swap", 2); err != nil {
+		return err
+	}
+	v := r.stack.values
+	n := len(v)
+	v[n-1], v[n-2] = v[n-2], v[n-1]
+	return nil
+}
+
+// Add replaces the top two values with their sum.
+func (r *RPN) Add() (int, error) { return r.Apply("add") }
+
+// Subtract replaces the top two values a, b (b on top) with a - b.
+func (r *RPN) Subtract() (int, error) { return r.Apply("subtract") }
+
+// Multiply replaces the top two values with their product.
+func (r *RPN) Multiply() (int, error) { return r.Apply("multiply") }
+
+// Divide replaces the top two values a, b (b on top) with a / b.
+func (r *RPN) Divide() (int, error) { return r.Apply("divide") }
+
+// Apply runs the named registry operation on the top Arity values, taken
+// in the order they were pushed, and pushes the result. On any error,
+// including a *StackUnderflowError, the stack is left unchanged.
+func (r *RPN) Apply(name string) (int, error) {
+	op, ok := r.calc.Registry().Lookup(name)
+	if !ok {
+		return 0, &UnknownOperationError{Name: name}
+	}
+	if err := r.need(name, op.Arity); err != nil {
+		return 0, err
+	}
+
+	base := len(r.stack.values) - op.Arity
+	operands := append([]int(nil), r.stack.values[base:]...)
+	result, err := r.calc.Registry().Apply(name, operands...)
+	if err != nil {
+		return 0, err
+	}
+	r.stack.values = append(r.stack.values[:base], result)
+	return result, nil
+}
+
+func (r *RPN) need(op string, n int) error {
+	if have := r.stack.Len(); have < n {
+		return &StackUnderflowError{Op: op, Need: n, Have: have}
+	}
+	return nil
+}
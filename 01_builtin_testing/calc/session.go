@@ -0,0 +1,108 @@
+package calc
+
+// ErrNothingToUndo is returned by Undo when no operation has been applied.
+var ErrNothingToUndo = Error("nothing to undo")
+
+// ErrNothingToRedo is returned by Redo when no operation has been undone.
+var ErrNothingToRedo = Error("nothing to redo")
+
+// Entry records one operation applied during a Session.
+// It marshals to JSON so a History can be stored or sent to a UI.
+type Entry struct {
+	Operation string `json:"operation"`
+	Operands  []int  `json:"operands"`
+	Result    int    `json:"result"`
+	Error     string `json:"error,omitempty"`
+
+	err error // the original error, kept for errors.Is after Undo/Redo
+}
+
+// Session records every operation applied through it and supports undoing
+// and redoing them. It also has a memory register (see MemoryAdd), which,
+// like on a physical calculator, is not affected by Undo or Redo.
+// Sessions are not safe for concurrent use.
+type Session struct {
+	calc    *Calculator
+	entries []Entry
+	applied int // entries[:applied] are in effect, entries[applied:] can be redone
+	memory  int
+}
+
+// NewSession starts a recording session on c.
+func (c *Calculator) NewSession() *Session {
+	return &Session{calc: c}
+}
+
+// Apply runs the named operation and records it, including failures.
+// Applying a new operation discards anything that could have been redone.
+func (s *Session) Apply(name string, operands ...int) (int, error) {
+	result, err := s.calc.Apply(name, operands...)
+
+	entry := Entry{
+		Operation: name,
+		Operands:  append([]int(nil), operands...),
+		Result:    result,
+		err:       err,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	s.record(entry)
+	return result, err
+}
+
+// record appends entry as the newest operation in effect.
+func (s *Session) record(entry Entry) {
+	s.entries = append(s.entries[:s.applied], entry)
+	s.applied++
+}
+
+// Value returns the result (or error) of the most recent operation still in
+// effect, or 0 if there is none.
+func (s *Session) Value() (int, error) {
+	if s.applied == 0 {
+		return 0, nil
+	}
+	last := s.entries[s.applied-1]
+	return last.Result, last.err
+}
+
+// Undo reverts the most recent operation still in effect.
+func (s *Session) Undo() error {
+	if s.applied == 0 {
+		return ErrNothingToUndo
+	}
+	s.applied--
+	return nil
+}
+
+// Redo reapplies the most recently undone operation, restoring its
+// recorded result.
+func (s *Session) Redo() error {
+	if s.applied == len(s.entries) {
+		return ErrNothingToRedo
+	}
+	s.applied++
+	return nil
+}
+
+// CanUndo reports whether Undo would succeed.
+func (s *Session) CanUndo() bool {
+	return s.applied > 0
+}
+
+// CanRedo reports whether Redo would succeed.
+func (s *Session) CanRedo() bool {
+	return s.applied < len(s.entries)
+}
+
+// History returns a copy of the operations currently in effect, oldest first.
+func (s *Session) History() []Entry {
+	history := make([]Entry, s.applied)
+	for i, entry := range s.entries[:s.applied] {
+		entry.Operands = append([]int(nil), entry.Operands...)
+		history[i] = entry
+	}
+	return history
+}
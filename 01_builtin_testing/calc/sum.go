@@ -1,21 +1,27 @@
 package calc
 
+import "github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
+
 // Sum returns the sum of two integers.
 // This is a simple function used to demonstrate Go's built-in testing package.
 func Sum(a, b int) int {
-	return a + b
+	return numeric.Sum(a, b)
+}
+
+// Subtract returns the difference of two integers.
+func Subtract(a, b int) int {
+	return numeric.Subtract(a, b)
 }
 
 // Multiply returns the product of two integers.
 func Multiply(a, b int) int {
-	return a * b
+	return numeric.Multiply(a, b)
 }
 
 // Divide returns the division of two integers.
-// Returns 0 if attempting to divide by zero.
+// Returns 0 if attempting to divide by zero; use DivideChecked to tell
+// that apart from a genuine zero quotient.
 func Divide(a, b int) int {
-	if b == 0 {
-		return 0
-	}
-	return a / b
+	q, _ := numeric.Divide(a, b)
+	return q
 }
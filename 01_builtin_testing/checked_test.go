@@ -0,0 +1,103 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// checkedCase describes one call to a checked or saturating operation.
+type checkedCase struct {
+	name      string
+	a, b      int
+	expected  int  // checked result when no overflow
+	overflow  bool // whether the checked variant must report overflow
+	saturated int  // result of the saturating variant
+}
+
+// TestSumChecked covers the int boundaries for overflow-checked addition.
+func TestSumChecked(t *testing.T) {
+	tests := []checkedCase{
+		{"small numbers", 2, 3, 5, false, 5},
+		{"max plus zero", math.MaxInt, 0, math.MaxInt, false, math.MaxInt},
+		{"min plus zero", math.MinInt, 0, math.MinInt, false, math.MinInt},
+		{"max plus min", math.MaxInt, math.MinInt, -1, false, -1},
+		{"max plus one", math.MaxInt, 1, 0, true, math.MaxInt},
+		{"max plus max", math.MaxInt, math.MaxInt, 0, true, math.MaxInt},
+		{"min minus one", math.MinInt, -1, 0, true, math.MinInt},
+		{"min plus min", math.MinInt, math.MinInt, 0, true, math.MinInt},
+	}
+
+	runChecked(t, "SumChecked", calc.SumChecked, calc.SumSaturating, tests)
+}
+
+// TestSubtractChecked covers the int boundaries for overflow-checked subtraction.
+func TestSubtractChecked(t *testing.T) {
+	tests := []checkedCase{
+		{"small numbers", 5, 3, 2, false, 2},
+		{"max minus max", math.MaxInt, math.MaxInt, 0, false, 0},
+		{"min minus min", math.MinInt, math.MinInt, 0, false, 0},
+		{"minus one minus min", -1, math.MinInt, math.MaxInt, false, math.MaxInt},
+		{"zero minus min", 0, math.MinInt, 0, true, math.MaxInt},
+		{"min minus one", math.MinInt, 1, 0, true, math.MinInt},
+		{"max minus min", math.MaxInt, math.MinInt, 0, true, math.MaxInt},
+		{"min minus max", math.MinInt, math.MaxInt, 0, true, math.MinInt},
+	}
+
+	runChecked(t, "SubtractChecked", calc.SubtractChecked, calc.SubtractSaturating, tests)
+}
+
+// TestMultiplyChecked covers the int boundaries for overflow-checked multiplication.
+func TestMultiplyChecked(t *testing.T) {
+	tests := []checkedCase{
+		{"small numbers", 3, 4, 12, false, 12},
+		{"max times one", math.MaxInt, 1, math.MaxInt, false, math.MaxInt},
+		{"min times one", math.MinInt, 1, math.MinInt, false, math.MinInt},
+		{"max times minus one", math.MaxInt, -1, -math.MaxInt, false, -math.MaxInt},
+		{"min times zero", math.MinInt, 0, 0, false, 0},
+		{"min times minus one", math.MinInt, -1, 0, true, math.MaxInt},
+		{"minus one times min", -1, math.MinInt, 0, true, math.MaxInt},
+		{"max times two", math.MaxInt, 2, 0, true, math.MaxInt},
+		{"min times two", math.MinInt, 2, 0, true, math.MinInt},
+		{"max times minus two", math.MaxInt, -2, 0, true, math.MinInt},
+		{"min times min", math.MinInt, math.MinInt, 0, true, math.MaxInt},
+	}
+
+	runChecked(t, "MultiplyChecked", calc.MultiplyChecked, calc.MultiplySaturating, tests)
+}
+
+// runChecked runs a table against a checked function and its saturating twin.
+func runChecked(t *testing.T, name string, checked func(a, b int) (int, error), saturating func(a, b int) int, tests []checkedCase) {
+	t.Helper()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checked(tt.a, tt.b)
+			if tt.overflow {
+				var overflowErr *calc.OverflowError
+				if !errors.As(err, &overflowErr) {
+					t.Fatalf("%s(%d, %d) error = %v; want *OverflowError", name, tt.a, tt.b, err)
+				}
+				if !errors.Is(err, calc.ErrOverflow) {
+					t.Errorf("%s(%d, %d) error does not wrap ErrOverflow", name, tt.a, tt.b)
+				}
+				if overflowErr.A != tt.a || overflowErr.B != tt.b {
+					t.Errorf("OverflowError operands = (%d, %d); want (%d, %d)", overflowErr.A, overflowErr.B, tt.a, tt.b)
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("%s(%d, %d) unexpected error: %v", name, tt.a, tt.b, err)
+				}
+				if result != tt.expected {
+					t.Errorf("%s(%d, %d) = %d; want %d", name, tt.a, tt.b, result, tt.expected)
+				}
+			}
+
+			if got := saturating(tt.a, tt.b); got != tt.saturated {
+				t.Errorf("saturating %s(%d, %d) = %d; want %d", name, tt.a, tt.b, got, tt.saturated)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package calc_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestDecimal_Round demonstrates the three rounding modes on ties and non-ties.
+func TestDecimal_Round(t *testing.T) {
+	tests := []struct {
+		in       string
+		mode     calc.RoundingMode
+		expected string
+	}{
+		{"2.345", calc.HalfEven, "2.34"},
+		{"2.355", calc.HalfEven, "2.36"},
+		{"2.346", calc.HalfEven, "2.35"},
+		{"-2.345", calc.HalfEven, "-2.34"},
+		{"2.345", calc.HalfUp, "2.35"},
+		{"-2.345", calc.HalfUp, "-2.35"},
+		{"2.344", calc.HalfUp, "2.34"},
+		{"2.349", calc.Down, "2.34"},
+		{"-2.349", calc.Down, "-2.34"},
+		{"0.005", calc.HalfEven, "0.00"},
+		{"-0.004", calc.HalfUp, "0.00"},
+		{"1.5", calc.HalfEven, "1.50"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in+" "+tt.mode.String(), func(t *testing.T) {
+			got := calc.MustParseDecimal(tt.in).Round(2, tt.mode).String()
+			if got != tt.expected {
+				t.Errorf("Round(%s, 2, %v) = %s; want %s", tt.in, tt.mode, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDecimal_Arithmetic demonstrates exact money arithmetic.
+func TestDecimal_Arithmetic(t *testing.T) {
+	price := calc.MustParseDecimal("19.99")
+	qty := calc.NewDecimal(3, 0)
+	vat := calc.MustParseDecimal("0.20")
+
+	net := price.Multiply(qty)
+	if net.String() != "59.97" {
+		t.Errorf("net = %s; want 59.97", net)
+	}
+
+	gross := net.Add(net.Multiply(vat)).Round(2, calc.HalfEven)
+	if gross.String() != "71.96" {
+		t.Errorf("gross = %s; want 71.96", gross)
+	}
+
+	if diff := gross.Subtract(net); diff.String() != "11.99" {
+		t.Errorf("gross - net = %s; want 11.99", diff)
+	}
+
+	share, err := calc.MustParseDecimal("100.00").Divide(calc.NewDecimal(3, 0), 2, calc.HalfEven)
+	if err != nil {
+		t.Fatalf("Divide unexpected error: %v", err)
+	}
+	if share.String() != "33.33" {
+		t.Errorf("100.00 / 3 = %s; want 33.33", share)
+	}
+
+	if _, err := price.Divide(calc.Decimal{}, 2, calc.HalfEven); !errors.Is(err, calc.ErrDivisionByZero) {
+		t.Errorf("Divide by zero error = %v; want ErrDivisionByZero", err)
+	}
+}
+
+// TestParseDecimal demonstrates parsing and formatting round trips.
+func TestParseDecimal(t *testing.T) {
+	valid := []string{"0", "12", "-12.30", "0.001", "+7.5", "123456789012345678901234567890.12"}
+	for _, s := range valid {
+		d, err := calc.ParseDecimal(s)
+		if err != nil {
+			t.Errorf("ParseDecimal(%q) unexpected error: %v", s, err)
+			continue
+		}
+		want := s
+		if want[0] == '+' {
+			want = want[1:]
+		}
+		if d.String() != want {
+			t.Errorf("ParseDecimal(%q).String() = %q", s, d.String())
+		}
+	}
+
+	invalid := []string{"", "-", ".5", "5.", "1.2.3", "12a", "--1", "-+1", "1e3"}
+	for _, s := range invalid {
+		if _, err := calc.ParseDecimal(s); !errors.Is(err, calc.ErrInvalidDecimal) {
+			t.Errorf("ParseDecimal(%q) error = %v; want ErrInvalidDecimal", s, err)
+		}
+	}
+}
+
+// decimalGen generates decimals with up to four digits after the point.
+func decimalGen() gopter.Gen {
+	return gopter.CombineGens(
+		gen.Int64Range(-1_000_000_000, 1_000_000_000),
+		gen.IntRange(0, 4),
+	).Map(func(values []interface{}) calc.Decimal {
+		return calc.NewDecimal(values[0].(int64), values[1].(int))
+	})
+}
+
+// nonZeroDecimalGen generates decimals that are safe to divide by.
+func nonZeroDecimalGen() gopter.Gen {
+	return decimalGen().SuchThat(func(d calc.Decimal) bool {
+		return d.Sign() != 0
+	})
+}
+
+// TestDecimalProperties checks algebraic laws with generated decimals.
+func TestDecimalProperties(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 200
+
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("parse inverts string", prop.ForAll(
+		func(d calc.Decimal) bool {
+			parsed, err := calc.ParseDecimal(d.String())
+			return err == nil && parsed.Cmp(d) == 0 && parsed.Scale() == d.Scale()
+		},
+		decimalGen(),
+	))
+
+	properties.Property("addition is commutative", prop.ForAll(
+		func(a, b calc.Decimal) bool {
+			return a.Add(b).Cmp(b.Add(a)) == 0
+		},
+		decimalGen(), decimalGen(),
+	))
+
+	properties.Property("addition is associative", prop.ForAll(
+		func(a, b, c calc.Decimal) bool {
+			return a.Add(b).Add(c).Cmp(a.Add(b.Add(c))) == 0
+		},
+		decimalGen(), decimalGen(), decimalGen(),
+	))
+
+	properties.Property("subtraction undoes addition", prop.ForAll(
+		func(a, b calc.Decimal) bool {
+			return a.Add(b).Subtract(b).Cmp(a) == 0
+		},
+		decimalGen(), decimalGen(),
+	))
+
+	properties.Property("multiplication is commutative", prop.ForAll(
+		func(a, b calc.Decimal) bool {
+			return a.Multiply(b).Cmp(b.Multiply(a)) == 0
+		},
+		decimalGen(), decimalGen(),
+	))
+
+	properties.Property("multiplication distributes over addition", prop.ForAll(
+		func(a, b, c calc.Decimal) bool {
+			return a.Multiply(b.Add(c)).Cmp(a.Multiply(b).Add(a.Multiply(c))) == 0
+		},
+		decimalGen(), decimalGen(), decimalGen(),
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestDecimalRoundingProperties checks invariants of each rounding mode.
+func TestDecimalRoundingProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	modes := gen.OneConstOf(calc.HalfEven, calc.HalfUp, calc.Down)
+
+	properties.Property("rounding is idempotent", prop.ForAll(
+		func(d calc.Decimal, scale int, mode calc.RoundingMode) bool {
+			once := d.Round(scale, mode)
+			return once.Round(scale, mode).Cmp(once) == 0
+		},
+		decimalGen(), gen.IntRange(0, 4), modes,
+	))
+
+	properties.Property("rounding sets the scale", prop.ForAll(
+		func(d calc.Decimal, scale int, mode calc.RoundingMode) bool {
+			return d.Round(scale, mode).Scale() == scale
+		},
+		decimalGen(), gen.IntRange(0, 6), modes,
+	))
+
+	properties.Property("rounding down never increases magnitude", prop.ForAll(
+		func(d calc.Decimal, scale int) bool {
+			rounded := d.Round(scale, calc.Down)
+			if d.Sign() >= 0 {
+				return rounded.Cmp(d) <= 0
+			}
+			return rounded.Cmp(d) >= 0
+		},
+		decimalGen(), gen.IntRange(0, 4),
+	))
+
+	properties.Property("rounding moves by at most one unit", prop.ForAll(
+		func(d calc.Decimal, scale int, mode calc.RoundingMode) bool {
+			diff := d.Round(scale, mode).Subtract(d)
+			unit := calc.NewDecimal(1, scale)
+			return diff.Cmp(unit) < 0 && diff.Cmp(calc.NewDecimal(-1, scale)) > 0
+		},
+		decimalGen(), gen.IntRange(0, 4), modes,
+	))
+
+	properties.Property("half-even and half-up agree off ties", prop.ForAll(
+		func(d calc.Decimal) bool {
+			// Shift to five places with a trailing 1; such a value can
+			// never sit exactly halfway between two-place neighbours.
+			fine := d.Round(4, calc.Down).Multiply(calc.NewDecimal(1, 1)).Add(calc.NewDecimal(1, 5))
+			return fine.Round(2, calc.HalfEven).Cmp(fine.Round(2, calc.HalfUp)) == 0
+		},
+		decimalGen(),
+	))
+
+	properties.Property("quotient times divisor is within half a unit", prop.ForAll(
+		func(a, b calc.Decimal) bool {
+			q, err := a.Divide(b, 4, calc.HalfEven)
+			if err != nil {
+				return false
+			}
+			// |q*b - a| <= |b| * 0.00005
+			residual := q.Multiply(b).Subtract(a)
+			bound := b.Multiply(calc.MustParseDecimal("0.00005"))
+			if bound.Sign() < 0 {
+				bound = calc.Decimal{}.Subtract(bound)
+			}
+			if residual.Sign() < 0 {
+				residual = calc.Decimal{}.Subtract(residual)
+			}
+			return residual.Cmp(bound) <= 0
+		},
+		decimalGen(), nonZeroDecimalGen(),
+	))
+
+	properties.TestingRun(t)
+}
@@ -0,0 +1,120 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestDivideChecked demonstrates distinguishing errors from a zero quotient.
+func TestDivideChecked(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     int
+		expected int
+		err      error
+	}{
+		{"normal division", 6, 2, 3, nil},
+		{"zero quotient", 1, 2, 0, nil},
+		{"truncates towards zero", -7, 2, -3, nil},
+		{"divide by zero", 5, 0, 0, calc.ErrDivisionByZero},
+		{"min by minus one", math.MinInt, -1, 0, calc.ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DivideChecked(tt.a, tt.b)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("DivideChecked(%d, %d) error = %v; want %v", tt.a, tt.b, err, tt.err)
+			}
+			if result != tt.expected {
+				t.Errorf("DivideChecked(%d, %d) = %d; want %d", tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestDivMod checks every sign combination under each rounding mode.
+func TestDivMod(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b int
+		mode calc.DivisionMode
+		q, r int
+	}{
+		{"truncated +/+", 7, 2, calc.Truncated, 3, 1},
+		{"truncated -/+", -7, 2, calc.Truncated, -3, -1},
+		{"truncated +/-", 7, -2, calc.Truncated, -3, 1},
+		{"truncated -/-", -7, -2, calc.Truncated, 3, -1},
+
+		{"floored +/+", 7, 2, calc.Floored, 3, 1},
+		{"floored -/+", -7, 2, calc.Floored, -4, 1},
+		{"floored +/-", 7, -2, calc.Floored, -4, -1},
+		{"floored -/-", -7, -2, calc.Floored, 3, -1},
+
+		{"euclidean +/+", 7, 2, calc.Euclidean, 3, 1},
+		{"euclidean -/+", -7, 2, calc.Euclidean, -4, 1},
+		{"euclidean +/-", 7, -2, calc.Euclidean, -3, 1},
+		{"euclidean -/-", -7, -2, calc.Euclidean, 4, 1},
+
+		{"exact division", -8, 2, calc.Floored, -4, 0},
+		{"min by max floored", math.MinInt, math.MaxInt, calc.Floored, -2, math.MaxInt - 1},
+		{"min by two euclidean", math.MinInt, 2, calc.Euclidean, math.MinInt / 2, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q, r, err := calc.DivMod(tt.a, tt.b, tt.mode)
+			if err != nil {
+				t.Fatalf("DivMod(%d, %d, %v) unexpected error: %v", tt.a, tt.b, tt.mode, err)
+			}
+			if q != tt.q || r != tt.r {
+				t.Errorf("DivMod(%d, %d, %v) = (%d, %d); want (%d, %d)", tt.a, tt.b, tt.mode, q, r, tt.q, tt.r)
+			}
+			if q*tt.b+r != tt.a {
+				t.Errorf("q*b + r = %d; want %d", q*tt.b+r, tt.a)
+			}
+
+			mod, err := calc.Mod(tt.a, tt.b, tt.mode)
+			if err != nil || mod != tt.r {
+				t.Errorf("Mod(%d, %d, %v) = (%d, %v); want %d", tt.a, tt.b, tt.mode, mod, err, tt.r)
+			}
+		})
+	}
+}
+
+// TestDivMod_Errors demonstrates the error cases shared by all modes.
+func TestDivMod_Errors(t *testing.T) {
+	for _, mode := range []calc.DivisionMode{calc.Truncated, calc.Floored, calc.Euclidean} {
+		t.Run(mode.String(), func(t *testing.T) {
+			if _, _, err := calc.DivMod(1, 0, mode); !errors.Is(err, calc.ErrDivisionByZero) {
+				t.Errorf("DivMod(1, 0) error = %v; want ErrDivisionByZero", err)
+			}
+			if _, err := calc.Mod(1, 0, mode); !errors.Is(err, calc.ErrDivisionByZero) {
+				t.Errorf("Mod(1, 0) error = %v; want ErrDivisionByZero", err)
+			}
+			if _, _, err := calc.DivMod(math.MinInt, -1, mode); !errors.Is(err, calc.ErrOverflow) {
+				t.Errorf("DivMod(MinInt, -1) error = %v; want ErrOverflow", err)
+			}
+			if r, err := calc.Mod(math.MinInt, -1, mode); err != nil || r != 0 {
+				t.Errorf("Mod(MinInt, -1) = (%d, %v); want (0, nil)", r, err)
+			}
+		})
+	}
+}
+
+// TestDivMod_UnknownMode checks that an undefined mode is rejected before
+// any shortcut, by DivMod and Mod alike.
+func TestDivMod_UnknownMode(t *testing.T) {
+	mode := calc.DivisionMode(9)
+	for _, b := range []int{2, 0, -1} {
+		if _, _, err := calc.DivMod(5, b, mode); !errors.Is(err, calc.ErrUnknownDivisionMode) {
+			t.Errorf("DivMod(5, %d, %d) error = %v; want ErrUnknownDivisionMode", b, mode, err)
+		}
+		if _, err := calc.Mod(5, b, mode); !errors.Is(err, calc.ErrUnknownDivisionMode) {
+			t.Errorf("Mod(5, %d, %d) error = %v; want ErrUnknownDivisionMode", b, mode, err)
+		}
+	}
+}
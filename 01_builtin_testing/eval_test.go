@@ -0,0 +1,258 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestEval demonstrates table-driven tests for the expression evaluator.
+func TestEval(t *testing.T) {
+	tests := []struct {
+		name     string
+		expr     string
+		expected int
+	}{
+		{"single number", "42", 42},
+		{"addition", "2 + 3", 5},
+		{"precedence", "2 + 3 * 4", 14},
+		{"left associative subtraction", "10 - 4 - 3", 3},
+		{"left associative division", "100 / 10 / 5", 2},
+		{"parentheses", "(2 + 3) * 4", 20},
+		{"example from the docs", "(2 + 3) * -4 / 2", -10},
+		{"unary minus", "-5", -5},
+		{"double negation", "--5", 5},
+		{"unary minus binds tighter than multiply", "-2 * -3", 6},
+		{"negated group", "-(2 + 3)", -5},
+		{"smallest int", "-9223372036854775808", math.MinInt},
+		{"smallest int in an expression", "1 + -9223372036854775808", math.MinInt + 1},
+		{"nested parentheses", "((1 + 2) * (3 + 4))", 21},
+		{"truncating division", "7 / 2", 3},
+		{"no whitespace", "1+2*3", 7},
+		{"extra whitespace", "  1 +\t2  ", 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) unexpected error: %v", tt.expr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("Eval(%q) = %d; want %d", tt.expr, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestEval_SyntaxErrors checks that each error points at the right character.
+func TestEval_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		pos     int
+		message string
+	}{
+		{"empty input", "", 1, `position 1: expected number, variable or "(", found end of input`},
+		{"dangling operator", "2 +", 4, `position 4: expected number, variable or "(", found end of input`},
+		{"unexpected character", "2 $ 3", 3, `position 3: unexpected character "$"`},
+		{"unclosed parenthesis", "(2 + 3", 1, "position 1: unclosed parenthesis"},
+		{"stray closing parenthesis", "2 + 3)", 6, `position 6: unexpected ")"`},
+		{"missing operator", "2 3", 3, `position 3: unexpected "3"`},
+		{"leading binary operator", "* 2", 1, `position 1: expected number, variable or "(", found "*"`},
+		{"number out of range", "99999999999999999999", 1, "position 1: number 99999999999999999999 out of range"},
+		{"negative number out of range", "-9223372036854775809", 2, "position 2: number -9223372036854775809 out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.Eval(tt.expr)
+
+			var syntaxErr *calc.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("Eval(%q) error = %v; want *SyntaxError", tt.expr, err)
+			}
+			if syntaxErr.Pos != tt.pos {
+				t.Errorf("Eval(%q) error position = %d; want %d", tt.expr, syntaxErr.Pos, tt.pos)
+			}
+			if err.Error() != tt.message {
+				t.Errorf("Eval(%q) error = %q; want %q", tt.expr, err.Error(), tt.message)
+			}
+		})
+	}
+}
+
+// TestEval_ArithmeticErrors shows Eval reuses the checked operations' errors.
+func TestEval_ArithmeticErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		err  error
+	}{
+		{"division by zero", "10 / 0", calc.ErrDivisionByZero},
+		{"division by zero expression", "1 / (2 - 2)", calc.ErrDivisionByZero},
+		{"overflow", "9223372036854775807 + 1", calc.ErrOverflow},
+		{"negation overflow", "-(-9223372036854775807 - 1)", calc.ErrOverflow},
+		{"negated smallest int", "--9223372036854775808", calc.ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.Eval(tt.expr)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Eval(%q) error = %v; want %v", tt.expr, err, tt.err)
+			}
+		})
+	}
+
+	// The message matches the one the BDD suites assert on.
+	_, err := calc.Eval("10 / 0")
+	if err == nil || err.Error() != "cannot divide by zero" {
+		t.Errorf("Eval(\"10 / 0\") error = %v; want \"cannot divide by zero\"", err)
+	}
+}
+
+// TestEvalEnv demonstrates resolving variables from an environment.
+func TestEvalEnv(t *testing.T) {
+	vars := map[string]int{"x": 5, "ans": 12, "rate_2": 3}
+
+	tests := []struct {
+		expr     string
+		expected int
+	}{
+		{"x", 5},
+		{"x * 2 + ans", 22},
+		{"-x", -5},
+		{"(ans - x) * rate_2", 21},
+	}
+
+	for _, tt := range tests {
+		result, err := calc.EvalEnv(tt.expr, vars)
+		if err != nil {
+			t.Errorf("EvalEnv(%q) unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("EvalEnv(%q) = %d; want %d", tt.expr, result, tt.expected)
+		}
+	}
+
+	_, err := calc.EvalEnv("x + y", vars)
+	var undefinedErr *calc.UndefinedError
+	if !errors.As(err, &undefinedErr) {
+		t.Fatalf("EvalEnv(\"x + y\") error = %v; want *UndefinedError", err)
+	}
+	if undefinedErr.Name != "y" || undefinedErr.Pos != 5 {
+		t.Errorf("UndefinedError = %+v; want y at position 5", undefinedErr)
+	}
+
+	if _, err := calc.Eval("x"); !errors.As(err, &undefinedErr) {
+		t.Errorf("Eval(\"x\") error = %v; want *UndefinedError", err)
+	}
+}
+
+// TestIsIdentifier checks which names are valid variables.
+func TestIsIdentifier(t *testing.T) {
+	for _, name := range []string{"x", "ans", "_tmp", "rate2"} {
+		if !calc.IsIdentifier(name) {
+			t.Errorf("IsIdentifier(%q) = false; want true", name)
+		}
+	}
+	for _, name := range []string{"", "2x", "a-b", "a b", "é"} {
+		if calc.IsIdentifier(name) {
+			t.Errorf("IsIdentifier(%q) = true; want false", name)
+		}
+	}
+}
+
+// TestEval_Calls demonstrates calling registered operations by name.
+func TestEval_Calls(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected int
+	}{
+		{"power(2, 10)", 1024},
+		{"gcd(12, 18) + lcm(4, 6)", 18},
+		{"modpow(4, 13, 497)", 445},
+		{"factorial(5) / isqrt(17)", 30},
+		{"-power(-2, 3)", 8},
+		{"add(1, multiply(2, 3))", 7},
+		{"mod(-7, 2)", -1},
+	}
+
+	for _, tt := range tests {
+		result, err := calc.Eval(tt.expr)
+		if err != nil {
+			t.Errorf("Eval(%q) unexpected error: %v", tt.expr, err)
+			continue
+		}
+		if result != tt.expected {
+			t.Errorf("Eval(%q) = %d; want %d", tt.expr, result, tt.expected)
+		}
+	}
+
+	// A variable and an operation can share a name: calls need parentheses.
+	if result, err := calc.EvalEnv("gcd(gcd, 4)", map[string]int{"gcd": 6}); err != nil || result != 2 {
+		t.Errorf("EvalEnv(\"gcd(gcd, 4)\") = %d, %v; want 2", result, err)
+	}
+}
+
+// TestEval_CallErrors checks positions and wrapped registry errors.
+func TestEval_CallErrors(t *testing.T) {
+	_, err := calc.Eval("1 + sqrt(4)")
+	var callErr *calc.CallError
+	var unknown *calc.UnknownOperationError
+	if !errors.As(err, &callErr) || !errors.As(err, &unknown) || callErr.Pos != 5 {
+		t.Fatalf("Eval(\"1 + sqrt(4)\") error = %v; want *CallError at position 5", err)
+	}
+	if err.Error() != "position 5: unknown operation: sqrt" {
+		t.Errorf("error message = %q", err.Error())
+	}
+
+	_, err = calc.Eval("gcd(1)")
+	var arity *calc.ArityError
+	if !errors.As(err, &arity) || arity.Want != 2 || arity.Got != 1 {
+		t.Errorf("Eval(\"gcd(1)\") error = %v; want *ArityError", err)
+	}
+
+	if _, err := calc.Eval("power(2, 1 / 0)"); !errors.Is(err, calc.ErrDivisionByZero) {
+		t.Errorf("division by zero in an argument: error = %v", err)
+	}
+	if _, err := calc.Eval("power(2, -1)"); !errors.Is(err, calc.ErrDomain) {
+		t.Errorf("power(2, -1) error = %v; want %v", err, calc.ErrDomain)
+	}
+
+	for expr, msg := range map[string]string{
+		"gcd(1, 2":  "position 4: unclosed parenthesis",
+		"gcd(1 2)":  `position 7: expected ")", found "2"`,
+		"gcd(1, )":  `position 8: expected number, variable or "(", found ")"`,
+		"gcd(, 1)":  `position 5: expected number, variable or "(", found ","`,
+		"1, 2":      `position 2: unexpected ","`,
+		"power()()": `position 8: unexpected "("`,
+	} {
+		_, err := calc.Eval(expr)
+		var syntaxErr *calc.SyntaxError
+		if !errors.As(err, &syntaxErr) || err.Error() != msg {
+			t.Errorf("Eval(%q) error = %v; want %q", expr, err, msg)
+		}
+	}
+}
+
+// TestCalculator_Eval checks that calls resolve in the calculator's registry.
+func TestCalculator_Eval(t *testing.T) {
+	r := calc.NewRegistry()
+	if err := r.Register(calc.Unary("double", func(a int) (int, error) { return calc.MultiplyChecked(a, 2) }, calc.ErrOverflow)); err != nil {
+		t.Fatal(err)
+	}
+	c := calc.NewCalculatorWithRegistry(r)
+
+	if result, err := c.Eval("double(x) + 1", map[string]int{"x": 20}); err != nil || result != 41 {
+		t.Errorf("Eval(\"double(x) + 1\") = %d, %v; want 41", result, err)
+	}
+	var unknown *calc.UnknownOperationError
+	if _, err := c.Eval("power(2, 2)", nil); !errors.As(err, &unknown) {
+		t.Errorf("Eval(\"power(2, 2)\") error = %v; want unknown operation", err)
+	}
+}
@@ -0,0 +1,328 @@
+package calc_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/jsonrpc"
+)
+
+// TestJSONRPC_Handle checks raw messages against the JSON-RPC 2.0 spec.
+func TestJSONRPC_Handle(t *testing.T) {
+	server := jsonrpc.NewServer(calc.NewCalculator())
+
+	tests := []struct {
+		name  string
+		msg   string
+		reply string // empty means no reply
+	}{
+		{"positional params", `{"jsonrpc":"2.0","method":"subtract","params":[42,23],"id":1}`,
+			`{"jsonrpc":"2.0","result":19,"id":1}`},
+		{"named params", `{"jsonrpc":"2.0","method":"subtract","params":{"operands":[23,42]},"id":"a"}`,
+			`{"jsonrpc":"2.0","result":-19,"id":"a"}`},
+		{"null id is still a request", `{"jsonrpc":"2.0","method":"add","params":[1,1],"id":null}`,
+			`{"jsonrpc":"2.0","result":2,"id":null}`},
+		{"notification", `{"jsonrpc":"2.0","method":"add","params":[1,2]}`, ""},
+		{"failed notification", `{"jsonrpc":"2.0","method":"divide","params":[1,0]}`, ""},
+		{"eval", `{"jsonrpc":"2.0","method":"eval","params":["gcd(12, 18) * 2"],"id":2}`,
+			`{"jsonrpc":"2.0","result":12,"id":2}`},
+		{"eval named", `{"jsonrpc":"2.0","method":"eval","params":{"expression":"2*(3+4)"},"id":3}`,
+			`{"jsonrpc":"2.0","result":14,"id":3}`},
+		{"division by zero", `{"jsonrpc":"2.0","method":"divide","params":[10,0],"id":4}`,
+			`{"jsonrpc":"2.0","error":{"code":1001,"message":"cannot divide by zero"},"id":4}`},
+		{"overflow", `{"jsonrpc":"2.0","method":"add","params":[9223372036854775807,1],"id":5}`,
+			`{"jsonrpc":"2.0","error":{"code":1002,"message":"add(9223372036854775807, 1): integer overflow"},"id":5}`},
+		{"expression error", `{"jsonrpc":"2.0","method":"eval","params":["2 +"],"id":6}`,
+			`{"jsonrpc":"2.0","error":{"code":1004,"message":"position 4: expected number, variable or \"(\", found end of input","data":{"position":4}},"id":6}`},
+		{"method not found", `{"jsonrpc":"2.0","method":"sqrt","params":[4],"id":7}`,
+			`{"jsonrpc":"2.0","error":{"code":-32601,"message":"unknown operation: sqrt"},"id":7}`},
+		{"wrong arity", `{"jsonrpc":"2.0","method":"add","params":[1],"id":8}`,
+			`{"jsonrpc":"2.0","error":{"code":-32602,"message":"add takes 2 numbers, got 1"},"id":8}`},
+		{"non-integer params", `{"jsonrpc":"2.0","method":"add","params":[1.5,2],"id":9}`,
+			`{"jsonrpc":"2.0","error":{"code":-32602,"message":"params must be an array of integers"},"id":9}`},
+		{"parse error", `{"jsonrpc":"2.0","method":"add","params":[1,2`,
+			`{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`},
+		{"wrong version", `{"jsonrpc":"1.0","method":"add","params":[1,2],"id":10}`,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":10}`},
+		{"method not a string", `{"jsonrpc":"2.0","method":1,"params":"bar"}`,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`},
+		{"object id", `{"jsonrpc":"2.0","method":"add","params":[1,2],"id":{}}`,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`},
+		{"empty batch", `[]`,
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}`},
+		{"invalid batch", `[1,2]`,
+			`[{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null}]`},
+		{"mixed batch", `[
+			{"jsonrpc":"2.0","method":"add","params":[1,2],"id":"1"},
+			{"jsonrpc":"2.0","method":"multiply","params":[7,3]},
+			{"foo":"boo"},
+			{"jsonrpc":"2.0","method":"divide","params":[1,0],"id":"2"}
+		]`,
+			`[{"jsonrpc":"2.0","result":3,"id":"1"},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid Request"},"id":null},` +
+				`{"jsonrpc":"2.0","error":{"code":1001,"message":"cannot divide by zero"},"id":"2"}]`},
+		{"notification batch", `[{"jsonrpc":"2.0","method":"add","params":[1,2]},{"jsonrpc":"2.0","method":"add","params":[3,4]}]`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reply := server.Handle([]byte(tt.msg))
+			if tt.reply == "" {
+				if reply != nil {
+					t.Fatalf("Handle() = %s; want no reply", reply)
+				}
+				return
+			}
+			assertJSONEqual(t, string(reply), tt.reply)
+		})
+	}
+}
+
+func assertJSONEqual(t *testing.T, got, want string) {
+	t.Helper()
+	var g, w interface{}
+	if err := json.Unmarshal([]byte(got), &g); err != nil {
+		t.Fatalf("invalid JSON %q: %v", got, err)
+	}
+	if err := json.Unmarshal([]byte(want), &w); err != nil {
+		t.Fatalf("invalid expected JSON %q: %v", want, err)
+	}
+	if !reflect.DeepEqual(g, w) {
+		t.Errorf("got  %s\nwant %s", got, want)
+	}
+}
+
+// TestJSONRPC_HTTP demonstrates the client against the HTTP transport.
+func TestJSONRPC_HTTP(t *testing.T) {
+	server := httptest.NewServer(jsonrpc.NewServer(calc.NewCalculator()))
+	defer server.Close()
+
+	client := jsonrpc.NewHTTPClient(server.URL, server.Client())
+	defer client.Close()
+	testClient(t, client)
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("GET status = %d; want %d", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+
+	resp, err = http.Post(server.URL, "application/json", strings.NewReader(`{"jsonrpc":"2.0","method":"add","params":[1,2]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("notification status = %d; want %d", resp.StatusCode, http.StatusNoContent)
+	}
+}
+
+// TestJSONRPC_TCP demonstrates the client against the raw TCP transport.
+func TestJSONRPC_TCP(t *testing.T) {
+	addr, stop := startTCPServer(t)
+	defer stop()
+
+	client, err := jsonrpc.Dial(context.Background(), "tcp", addr)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+	testClient(t, client)
+
+	// Calls from many goroutines share the connection safely.
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if got, err := client.Call(context.Background(), "multiply", i, 2); err != nil || got != 2*i {
+				t.Errorf("Call(multiply, %d, 2) = %d, %v", i, got, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// TestJSONRPC_TCPRejectedMessages checks that messages the server must
+// reject leave the connection in step for the calls that follow.
+func TestJSONRPC_TCPRejectedMessages(t *testing.T) {
+	addr, stop := startTCPServer(t)
+	defer stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := jsonrpc.Dial(ctx, "tcp", addr)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer client.Close()
+
+	if _, err := client.Batch(ctx, nil); !errors.Is(err, jsonrpc.ErrEmptyBatch) {
+		t.Errorf("Batch(nil) error = %v; want %v", err, jsonrpc.ErrEmptyBatch)
+	}
+	var rpcErr *jsonrpc.Error
+	if err := client.Notify(ctx, ""); !errors.As(err, &rpcErr) || rpcErr.Code != jsonrpc.CodeInvalidRequest {
+		t.Errorf("Notify(\"\") error = %v; want code %d", err, jsonrpc.CodeInvalidRequest)
+	}
+	_, err = client.Batch(ctx, []jsonrpc.BatchCall{
+		{Method: "add", Operands: []int{1, 1}, Notify: true},
+		{Method: "", Notify: true},
+	})
+	if !errors.As(err, &rpcErr) || rpcErr.Code != jsonrpc.CodeInvalidRequest {
+		t.Errorf("Batch() with an invalid notification error = %v; want code %d", err, jsonrpc.CodeInvalidRequest)
+	}
+
+	for i := 0; i < 2; i++ {
+		if got, err := client.Call(ctx, "add", 2, 3); err != nil || got != 5 {
+			t.Fatalf("Call(add, 2, 3) = %d, %v; want 5", got, err)
+		}
+	}
+}
+
+// TestJSONRPC_TCPParseError checks that invalid JSON gets a parse error and
+// the connection is closed, since the stream cannot be resynchronised.
+func TestJSONRPC_TCPParseError(t *testing.T) {
+	addr, stop := startTCPServer(t)
+	defer stop()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if _, err := conn.Write([]byte("{\"jsonrpc\":\"2.0\",\"method\":\"add\",\"params\":[1,1],\"id\":1}\n{oops}\n")); err != nil {
+		t.Fatal(err)
+	}
+	r := bufio.NewReader(conn)
+	first, _ := r.ReadString('\n')
+	assertJSONEqual(t, first, `{"jsonrpc":"2.0","result":2,"id":1}`)
+	second, _ := r.ReadString('\n')
+	assertJSONEqual(t, second, `{"jsonrpc":"2.0","error":{"code":-32700,"message":"Parse error"},"id":null}`)
+	if _, err := r.ReadString('\n'); err == nil {
+		t.Error("connection still open after a parse error")
+	}
+}
+
+// TestJSONRPC_Close checks that Close stops Serve and drops connections.
+func TestJSONRPC_Close(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := jsonrpc.NewServer(calc.NewCalculator())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(l) }()
+
+	client, err := jsonrpc.Dial(context.Background(), "tcp", l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+	if _, err := client.Call(context.Background(), "add", 1, 1); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+
+	server.Close()
+	if err := <-done; err != nil {
+		t.Errorf("Serve() after Close = %v; want nil", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Call(ctx, "add", 1, 1); err == nil {
+		t.Error("Call() on a closed server succeeded")
+	}
+	if err := server.Serve(l); !errors.Is(err, net.ErrClosed) {
+		t.Errorf("Serve() on a closed server = %v; want %v", err, net.ErrClosed)
+	}
+}
+
+func startTCPServer(t *testing.T) (addr string, stop func()) {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := jsonrpc.NewServer(calc.NewCalculator())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = server.Serve(l)
+	}()
+	return l.Addr().String(), func() {
+		server.Close()
+		<-done
+	}
+}
+
+// testClient runs the same checks over any transport.
+func testClient(t *testing.T, client *jsonrpc.Client) {
+	t.Helper()
+	ctx := context.Background()
+
+	if got, err := client.Call(ctx, "add", 2, 3); err != nil || got != 5 {
+		t.Errorf("Call(add, 2, 3) = %d, %v; want 5", got, err)
+	}
+	if got, err := client.Call(ctx, "factorial", 5); err != nil || got != 120 {
+		t.Errorf("Call(factorial, 5) = %d, %v; want 120", got, err)
+	}
+	if got, err := client.Eval(ctx, "(2 + 3) * -4 / 2"); err != nil || got != -10 {
+		t.Errorf("Eval() = %d, %v; want -10", got, err)
+	}
+
+	_, err := client.Call(ctx, "divide", 10, 0)
+	var rpcErr *jsonrpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != jsonrpc.CodeDivisionByZero {
+		t.Errorf("Call(divide, 10, 0) error = %v; want code %d", err, jsonrpc.CodeDivisionByZero)
+	}
+	if !errors.Is(err, calc.ErrDivisionByZero) || err.Error() != "cannot divide by zero" {
+		t.Errorf("Call(divide, 10, 0) error = %v; want %v", err, calc.ErrDivisionByZero)
+	}
+
+	if _, err := client.Call(ctx, "sqrt", 4); !errors.As(err, &rpcErr) || rpcErr.Code != jsonrpc.CodeMethodNotFound {
+		t.Errorf("Call(sqrt) error = %v; want code %d", err, jsonrpc.CodeMethodNotFound)
+	}
+	if _, err := client.Eval(ctx, "1 +"); !errors.As(err, &rpcErr) || rpcErr.Data == nil || rpcErr.Data.Position != 4 {
+		t.Errorf("Eval(\"1 +\") error = %v; want an expression error at position 4", err)
+	}
+
+	if err := client.Notify(ctx, "add", 1, 2); err != nil {
+		t.Errorf("Notify() error = %v", err)
+	}
+
+	results, err := client.Batch(ctx, []jsonrpc.BatchCall{
+		{Method: "add", Operands: []int{1, 2}},
+		{Method: "multiply", Operands: []int{3, 4}, Notify: true},
+		{Method: "divide", Operands: []int{1, 0}},
+		{Method: "power", Operands: []int{2, 8}},
+	})
+	if err != nil {
+		t.Fatalf("Batch() error = %v", err)
+	}
+	if results[0].Result != 3 || results[0].Err != nil || results[3].Result != 256 {
+		t.Errorf("Batch() results = %+v", results)
+	}
+	if !errors.Is(results[2].Err, calc.ErrDivisionByZero) {
+		t.Errorf("Batch() divide error = %v; want %v", results[2].Err, calc.ErrDivisionByZero)
+	}
+
+	if _, err := client.Batch(ctx, []jsonrpc.BatchCall{{Method: "add", Operands: []int{1, 1}, Notify: true}}); err != nil {
+		t.Errorf("notification-only Batch() error = %v", err)
+	}
+}
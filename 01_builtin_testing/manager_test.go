@@ -0,0 +1,217 @@
+package calc_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// fakeClock is a manually advanced clock that is safe for concurrent use.
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestManager(t *testing.T, ttl time.Duration) (*calc.SessionManager, *fakeClock) {
+	t.Helper()
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	return calc.NewSessionManagerWithClock(calc.NewCalculator(), ttl, clock.Now), clock
+}
+
+// TestSessionManager demonstrates that sessions are isolated by ID.
+func TestSessionManager(t *testing.T) {
+	m, _ := newTestManager(t, time.Minute)
+
+	alice, err := m.Create()
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	bob, _ := m.Create()
+	if alice == bob {
+		t.Fatalf("Create() returned duplicate ID %q", alice)
+	}
+
+	if _, err := m.Apply(alice, "add", 2, 3); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+	if _, err := m.Apply(bob, "divide", 1, 0); !errors.Is(err, calc.ErrDivisionByZero) {
+		t.Fatalf("Apply(divide by zero) error = %v", err)
+	}
+
+	_ = m.Do(alice, func(s *calc.Session) error {
+		if v, err := s.Value(); v != 5 || err != nil {
+			t.Errorf("alice Value() = %d, %v; want 5, nil", v, err)
+		}
+		return nil
+	})
+	_ = m.Do(bob, func(s *calc.Session) error {
+		if len(s.History()) != 1 {
+			t.Errorf("bob History() has %d entries; want 1", len(s.History()))
+		}
+		return nil
+	})
+
+	if err := m.Close(alice); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if _, err := m.Apply(alice, "add", 1, 1); !errors.Is(err, calc.ErrSessionNotFound) {
+		t.Errorf("Apply() after Close error = %v; want %v", err, calc.ErrSessionNotFound)
+	}
+	if err := m.Close(alice); !errors.Is(err, calc.ErrSessionNotFound) {
+		t.Errorf("second Close() error = %v; want %v", err, calc.ErrSessionNotFound)
+	}
+}
+
+// TestSessionManager_Expiry demonstrates idle expiry with an injected clock.
+func TestSessionManager_Expiry(t *testing.T) {
+	m, clock := newTestManager(t, time.Minute)
+
+	idle, _ := m.Create()
+	active, _ := m.Create()
+
+	clock.Advance(40 * time.Second)
+	if _, err := m.Apply(active, "add", 1, 1); err != nil {
+		t.Fatalf("Apply() error = %v", err)
+	}
+
+	clock.Advance(30 * time.Second)
+	if removed := m.Sweep(); removed != 1 {
+		t.Errorf("Sweep() removed %d sessions; want 1", removed)
+	}
+	if _, err := m.Apply(idle, "add", 1, 1); !errors.Is(err, calc.ErrSessionNotFound) {
+		t.Errorf("Apply() on expired session error = %v; want %v", err, calc.ErrSessionNotFound)
+	}
+
+	// Expired sessions are also rejected before a sweep runs.
+	clock.Advance(time.Minute)
+	if _, err := m.Apply(active, "add", 1, 1); !errors.Is(err, calc.ErrSessionNotFound) {
+		t.Errorf("Apply() on expired session error = %v; want %v", err, calc.ErrSessionNotFound)
+	}
+	if m.Len() != 0 {
+		t.Errorf("Len() = %d; want 0", m.Len())
+	}
+}
+
+// TestSessionManager_NoExpiryWhileInUse checks that a busy session is kept.
+func TestSessionManager_NoExpiryWhileInUse(t *testing.T) {
+	m, clock := newTestManager(t, time.Minute)
+	id, _ := m.Create()
+
+	err := m.Do(id, func(*calc.Session) error {
+		clock.Advance(time.Hour)
+		if removed := m.Sweep(); removed != 0 {
+			t.Errorf("Sweep() removed %d in-use sessions", removed)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	if _, err := m.Apply(id, "add", 1, 1); err != nil {
+		t.Errorf("Apply() right after use error = %v", err)
+	}
+}
+
+// TestSessionManager_Concurrent is meant to be run with -race.
+func TestSessionManager_Concurrent(t *testing.T) {
+	m, clock := newTestManager(t, time.Minute)
+
+	const users, ops = 8, 50
+	ids := make([]string, users)
+	for i := range ids {
+		ids[i], _ = m.Create()
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		for w := 0; w < 2; w++ {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				for i := 0; i < ops; i++ {
+					if _, err := m.Apply(id, "add", i, 1); err != nil {
+						t.Errorf("Apply() error = %v", err)
+						return
+					}
+				}
+			}(id)
+		}
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < ops; i++ {
+			clock.Advance(time.Millisecond)
+			m.Sweep()
+		}
+	}()
+	wg.Wait()
+
+	for _, id := range ids {
+		_ = m.Do(id, func(s *calc.Session) error {
+			if n := len(s.History()); n != 2*ops {
+				t.Errorf("session %s has %d entries; want %d", id, n, 2*ops)
+			}
+			return nil
+		})
+	}
+}
+
+// TestSessionManager_RunJanitor checks the janitor sweeps and stops on cancel.
+func TestSessionManager_RunJanitor(t *testing.T) {
+	m, clock := newTestManager(t, time.Minute)
+	_, _ = m.Create()
+	clock.Advance(2 * time.Minute)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.RunJanitor(ctx, time.Millisecond)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for m.Len() != 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if m.Len() != 0 {
+		t.Errorf("Len() = %d after janitor ran; want 0", m.Len())
+	}
+}
+
+// TestSessionManager_RunJanitorNoInterval checks that a non-positive
+// interval falls back to the TTL instead of panicking, and that without
+// expiry the janitor returns at once.
+func TestSessionManager_RunJanitorNoInterval(t *testing.T) {
+	noExpiry := calc.NewSessionManager(calc.NewCalculator(), 0)
+	noExpiry.RunJanitor(context.Background(), 0) // must return
+
+	m := calc.NewSessionManager(calc.NewCalculator(), time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		m.RunJanitor(ctx, -time.Second)
+		close(done)
+	}()
+	cancel()
+	<-done
+}
@@ -0,0 +1,96 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestMathPack demonstrates the built-in extra operations and their
+// boundaries through the default registry.
+func TestMathPack(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		operands []int
+		expected int
+		err      error
+	}{
+		{"power", "power", []int{3, 4}, 81, nil},
+		{"power of negative base", "power", []int{-2, 63}, math.MinInt, nil},
+		{"power zero to zero", "power", []int{0, 0}, 1, nil},
+		{"power negative exponent", "power", []int{2, -1}, 0, calc.ErrDomain},
+		{"power overflow", "power", []int{2, 63}, 0, calc.ErrOverflow},
+		{"gcd", "gcd", []int{12, -18}, 6, nil},
+		{"gcd of zeros", "gcd", []int{0, 0}, 0, nil},
+		{"gcd of MinInt", "gcd", []int{math.MinInt, 0}, 0, calc.ErrOverflow},
+		{"lcm", "lcm", []int{-4, 6}, 12, nil},
+		{"lcm with zero", "lcm", []int{0, 7}, 0, nil},
+		{"lcm overflow", "lcm", []int{math.MaxInt, 2}, 0, calc.ErrOverflow},
+		{"modpow", "modpow", []int{2, 10, 1000}, 24, nil},
+		{"modpow negative base", "modpow", []int{-2, 3, 5}, 2, nil},
+		{"modpow large modulus", "modpow", []int{math.MaxInt - 1, 2, math.MaxInt}, 1, nil},
+		{"modpow modulus one", "modpow", []int{5, 0, 1}, 0, nil},
+		{"modpow zero modulus", "modpow", []int{2, 3, 0}, 0, calc.ErrDivisionByZero},
+		{"modpow negative exponent", "modpow", []int{2, -3, 5}, 0, calc.ErrDomain},
+		{"factorial", "factorial", []int{20}, 2432902008176640000, nil},
+		{"factorial of zero", "factorial", []int{0}, 1, nil},
+		{"factorial overflow", "factorial", []int{21}, 0, calc.ErrOverflow},
+		{"factorial of negative", "factorial", []int{-1}, 0, calc.ErrDomain},
+		{"isqrt", "isqrt", []int{99}, 9, nil},
+		{"isqrt of MaxInt", "isqrt", []int{math.MaxInt}, 3037000499, nil},
+		{"isqrt of negative", "isqrt", []int{-4}, 0, calc.ErrDomain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := calc.DefaultRegistry.Apply(tt.op, tt.operands...)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("%s%v error = %v; want %v", tt.op, tt.operands, err, tt.err)
+			}
+			var contract *calc.ContractError
+			if errors.As(err, &contract) {
+				t.Fatalf("%s%v broke its error contract: %v", tt.op, tt.operands, err)
+			}
+			if result != tt.expected {
+				t.Errorf("%s%v = %d; want %d", tt.op, tt.operands, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRegistry_ErrorContract demonstrates how undeclared errors are reported.
+func TestRegistry_ErrorContract(t *testing.T) {
+	errBroken := errors.New("broken")
+	r := calc.NewRegistry()
+	err := r.RegisterAll(
+		calc.Unary("checked", func(int) (int, error) { return 0, calc.ErrDomain }, calc.ErrDomain),
+		calc.Unary("leaky", func(int) (int, error) { return 0, errBroken }, calc.ErrDomain),
+	)
+	if err != nil {
+		t.Fatalf("RegisterAll() error = %v", err)
+	}
+
+	_, err = r.Apply("checked", 1)
+	var contract *calc.ContractError
+	if !errors.Is(err, calc.ErrDomain) || errors.As(err, &contract) {
+		t.Errorf("declared error = %v; want %v unwrapped", err, calc.ErrDomain)
+	}
+
+	_, err = r.Apply("leaky", 1)
+	if !errors.As(err, &contract) || contract.Name != "leaky" || !errors.Is(err, errBroken) {
+		t.Errorf("undeclared error = %v; want *ContractError wrapping %v", err, errBroken)
+	}
+	if err.Error() != "leaky: undeclared error: broken" {
+		t.Errorf("message = %q", err.Error())
+	}
+
+	if err := r.RegisterAll(calc.MathPack()...); err != nil {
+		t.Fatalf("RegisterAll(MathPack()) error = %v", err)
+	}
+	if err := r.RegisterAll(calc.MathPack()...); err == nil {
+		t.Error("registering the pack twice should fail")
+	}
+}
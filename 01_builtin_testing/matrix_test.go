@@ -0,0 +1,105 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestVectorOperations demonstrates element-wise arithmetic on slices.
+func TestVectorOperations(t *testing.T) {
+	a, b := []int{1, 2, 3}, []int{4, -5, 6}
+
+	if got, err := calc.AddVectors(a, b); err != nil || !reflect.DeepEqual(got, []int{5, -3, 9}) {
+		t.Errorf("AddVectors = %v, %v", got, err)
+	}
+	if got, err := calc.SubtractVectors(a, b); err != nil || !reflect.DeepEqual(got, []int{-3, 7, -3}) {
+		t.Errorf("SubtractVectors = %v, %v", got, err)
+	}
+	if got, err := calc.MultiplyVectors(a, b); err != nil || !reflect.DeepEqual(got, []int{4, -10, 18}) {
+		t.Errorf("MultiplyVectors = %v, %v", got, err)
+	}
+	if got, err := calc.Dot(a, b); err != nil || got != 12 {
+		t.Errorf("Dot = %d, %v; want 12", got, err)
+	}
+	if got, err := calc.AddVectors(nil, []int{}); err != nil || len(got) != 0 {
+		t.Errorf("AddVectors of empty vectors = %v, %v", got, err)
+	}
+
+	// Intermediate sums may overflow as long as the result fits.
+	if got, err := calc.Dot([]int{math.MaxInt, 1, -1}, []int{1, 1, 1}); err != nil || got != math.MaxInt {
+		t.Errorf("Dot with intermediate overflow = %d, %v; want MaxInt", got, err)
+	}
+	if _, err := calc.Dot([]int{math.MaxInt, 1}, []int{1, 1}); !errors.Is(err, calc.ErrOverflow) {
+		t.Errorf("Dot overflow error = %v; want %v", err, calc.ErrOverflow)
+	}
+	if _, err := calc.AddVectors([]int{math.MaxInt}, []int{1}); !errors.Is(err, calc.ErrOverflow) {
+		t.Errorf("AddVectors overflow error = %v; want %v", err, calc.ErrOverflow)
+	}
+
+	_, err := calc.AddVectors([]int{1, 2}, []int{1})
+	var dimErr *calc.DimensionError
+	if !errors.As(err, &dimErr) || dimErr.Want != 2 || dimErr.Got != 1 {
+		t.Fatalf("AddVectors length mismatch error = %v; want *DimensionError", err)
+	}
+	if err.Error() != "add: dimension mismatch: want 2, got 1" {
+		t.Errorf("message = %q", err.Error())
+	}
+}
+
+// TestMatrixOperations demonstrates transpose, product and determinant.
+func TestMatrixOperations(t *testing.T) {
+	a := calc.Matrix{{1, 2, 3}, {4, 5, 6}}
+	b := calc.Matrix{{7, 8}, {9, 10}, {11, 12}}
+
+	if got, err := calc.Transpose(a); err != nil || !reflect.DeepEqual(got, calc.Matrix{{1, 4}, {2, 5}, {3, 6}}) {
+		t.Errorf("Transpose = %v, %v", got, err)
+	}
+	if got, err := calc.MatMul(a, b); err != nil || !reflect.DeepEqual(got, calc.Matrix{{58, 64}, {139, 154}}) {
+		t.Errorf("MatMul = %v, %v", got, err)
+	}
+
+	var dimErr *calc.DimensionError
+	if _, err := calc.MatMul(a, a); !errors.As(err, &dimErr) || dimErr.Op != "matmul" {
+		t.Errorf("MatMul(2x3, 2x3) error = %v; want *DimensionError", err)
+	}
+	if _, err := calc.Transpose(calc.Matrix{{1, 2}, {3}}); !errors.As(err, &dimErr) {
+		t.Errorf("Transpose of a ragged matrix error = %v; want *DimensionError", err)
+	}
+	if _, err := calc.Determinant(a); !errors.As(err, &dimErr) || dimErr.Want != 2 || dimErr.Got != 3 {
+		t.Errorf("Determinant of a 2x3 matrix error = %v; want *DimensionError", err)
+	}
+}
+
+// TestDeterminant demonstrates Bareiss elimination including pivoting.
+func TestDeterminant(t *testing.T) {
+	tests := []struct {
+		name     string
+		m        calc.Matrix
+		expected int
+		err      error
+	}{
+		{"empty", calc.Matrix{}, 1, nil},
+		{"1x1", calc.Matrix{{-7}}, -7, nil},
+		{"2x2", calc.Matrix{{3, 8}, {4, 6}}, -14, nil},
+		{"3x3", calc.Matrix{{6, 1, 1}, {4, -2, 5}, {2, 8, 7}}, -306, nil},
+		{"needs a row swap", calc.Matrix{{0, 1}, {1, 0}}, -1, nil},
+		{"singular", calc.Matrix{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}}, 0, nil},
+		{"zero column", calc.Matrix{{0, 1}, {0, 2}}, 0, nil},
+		{"identity", calc.Matrix{{1, 0, 0, 0}, {0, 1, 0, 0}, {0, 0, 1, 0}, {0, 0, 0, 1}}, 1, nil},
+		{"large entries that still fit", calc.Matrix{{math.MaxInt, 0}, {0, 1}}, math.MaxInt, nil},
+		{"overflow", calc.Matrix{{math.MaxInt, 0}, {0, 2}}, 0, calc.ErrOverflow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calc.Determinant(tt.m)
+			if !errors.Is(err, tt.err) || got != tt.expected {
+				t.Errorf("Determinant(%v) = %d, %v; want %d, %v", tt.m, got, err, tt.expected, tt.err)
+			}
+		})
+	}
+}
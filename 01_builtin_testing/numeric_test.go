@@ -0,0 +1,167 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
+)
+
+// numericCase uses small non-negative operands that every Number type can hold.
+type numericCase struct {
+	name                   string
+	a, b                   int
+	sum, product, quotient int
+}
+
+var numericCases = []numericCase{
+	{"small numbers", 6, 3, 9, 18, 2},
+	{"identity", 7, 1, 8, 7, 7},
+	{"zero dividend", 0, 5, 5, 0, 0},
+	{"largest int8 product", 25, 5, 30, 125, 5},
+}
+
+// runNumericCases runs the shared table against one instantiation.
+func runNumericCases[T numeric.Number](t *testing.T) {
+	t.Helper()
+
+	for _, tt := range numericCases {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := T(tt.a), T(tt.b)
+
+			if got := numeric.Sum(a, b); got != T(tt.sum) {
+				t.Errorf("Sum(%v, %v) = %v; want %v", a, b, got, tt.sum)
+			}
+			if got := numeric.Multiply(a, b); got != T(tt.product) {
+				t.Errorf("Multiply(%v, %v) = %v; want %v", a, b, got, tt.product)
+			}
+			if got := numeric.Subtract(T(tt.sum), b); got != a {
+				t.Errorf("Subtract(%v, %v) = %v; want %v", tt.sum, b, got, a)
+			}
+
+			quotient, err := numeric.Divide(a, b)
+			if err != nil || quotient != T(tt.quotient) {
+				t.Errorf("Divide(%v, %v) = (%v, %v); want %v", a, b, quotient, err, tt.quotient)
+			}
+
+			if _, err := numeric.SumChecked(a, b); err != nil {
+				t.Errorf("SumChecked(%v, %v) unexpected error: %v", a, b, err)
+			}
+			if _, err := numeric.MultiplyChecked(a, b); err != nil {
+				t.Errorf("MultiplyChecked(%v, %v) unexpected error: %v", a, b, err)
+			}
+		})
+	}
+}
+
+// TestNumeric_SharedTable runs the same table for every number type.
+func TestNumeric_SharedTable(t *testing.T) {
+	t.Run("int", runNumericCases[int])
+	t.Run("int8", runNumericCases[int8])
+	t.Run("int16", runNumericCases[int16])
+	t.Run("int32", runNumericCases[int32])
+	t.Run("int64", runNumericCases[int64])
+	t.Run("uint", runNumericCases[uint])
+	t.Run("uint8", runNumericCases[uint8])
+	t.Run("uint16", runNumericCases[uint16])
+	t.Run("uint32", runNumericCases[uint32])
+	t.Run("uint64", runNumericCases[uint64])
+	t.Run("uintptr", runNumericCases[uintptr])
+	t.Run("float32", runNumericCases[float32])
+	t.Run("float64", runNumericCases[float64])
+}
+
+// TestNumeric_DivisionByZero shows integers fail while floats follow IEEE 754.
+func TestNumeric_DivisionByZero(t *testing.T) {
+	t.Run("integers return an error", func(t *testing.T) {
+		if _, err := numeric.Divide(5, 0); !errors.Is(err, numeric.ErrDivisionByZero) {
+			t.Errorf("Divide[int] error = %v; want ErrDivisionByZero", err)
+		}
+		if _, err := numeric.Divide(uint8(5), 0); !errors.Is(err, numeric.ErrDivisionByZero) {
+			t.Errorf("Divide[uint8] error = %v; want ErrDivisionByZero", err)
+		}
+	})
+
+	t.Run("floats return infinity or NaN", func(t *testing.T) {
+		q, err := numeric.Divide(1.0, 0)
+		if err != nil || !math.IsInf(q, 1) {
+			t.Errorf("Divide(1.0, 0) = (%v, %v); want +Inf", q, err)
+		}
+		q32, err := numeric.DivideChecked(float32(-1), 0)
+		if err != nil || !math.IsInf(float64(q32), -1) {
+			t.Errorf("DivideChecked(float32(-1), 0) = (%v, %v); want -Inf", q32, err)
+		}
+		nan, err := numeric.Divide(0.0, 0)
+		if err != nil || !math.IsNaN(nan) {
+			t.Errorf("Divide(0.0, 0) = (%v, %v); want NaN", nan, err)
+		}
+	})
+}
+
+// TestNumeric_Overflow checks wrapping and checked behaviour at each type's bounds.
+func TestNumeric_Overflow(t *testing.T) {
+	t.Run("int8", func(t *testing.T) {
+		if got := numeric.Sum(int8(math.MaxInt8), 1); got != math.MinInt8 {
+			t.Errorf("Sum wraps to %d; want %d", got, math.MinInt8)
+		}
+		assertOverflow(t, "SumChecked", numeric.SumChecked[int8], math.MaxInt8, 1)
+		assertOverflow(t, "SubtractChecked", numeric.SubtractChecked[int8], math.MinInt8, 1)
+		assertOverflow(t, "MultiplyChecked", numeric.MultiplyChecked[int8], math.MinInt8, -1)
+		assertOverflow(t, "MultiplyChecked", numeric.MultiplyChecked[int8], 16, 8)
+		assertOverflow(t, "DivideChecked", numeric.DivideChecked[int8], math.MinInt8, -1)
+	})
+
+	t.Run("int64", func(t *testing.T) {
+		assertOverflow(t, "SumChecked", numeric.SumChecked[int64], math.MinInt64, -1)
+		assertOverflow(t, "MultiplyChecked", numeric.MultiplyChecked[int64], -1, math.MinInt64)
+		if got, err := numeric.MultiplyChecked[int64](math.MaxInt64, -1); err != nil || got != -math.MaxInt64 {
+			t.Errorf("MultiplyChecked(MaxInt64, -1) = (%d, %v)", got, err)
+		}
+	})
+
+	t.Run("uint8", func(t *testing.T) {
+		if got := numeric.Subtract(uint8(0), 1); got != math.MaxUint8 {
+			t.Errorf("Subtract wraps to %d; want %d", got, math.MaxUint8)
+		}
+		assertOverflow(t, "SumChecked", numeric.SumChecked[uint8], math.MaxUint8, 1)
+		assertOverflow(t, "SubtractChecked", numeric.SubtractChecked[uint8], 0, 1)
+		assertOverflow(t, "MultiplyChecked", numeric.MultiplyChecked[uint8], 16, 16)
+		if got, err := numeric.MultiplyChecked[uint8](15, 17); err != nil || got != 255 {
+			t.Errorf("MultiplyChecked(15, 17) = (%d, %v); want 255", got, err)
+		}
+	})
+
+	t.Run("uint64", func(t *testing.T) {
+		assertOverflow(t, "SumChecked", numeric.SumChecked[uint64], math.MaxUint64, 1)
+		assertOverflow(t, "MultiplyChecked", numeric.MultiplyChecked[uint64], 1<<32, 1<<32)
+	})
+
+	t.Run("float32", func(t *testing.T) {
+		if got := numeric.Sum(float32(math.MaxFloat32), math.MaxFloat32); !math.IsInf(float64(got), 1) {
+			t.Errorf("Sum = %v; want +Inf", got)
+		}
+		assertOverflow(t, "SumChecked", numeric.SumChecked[float32], math.MaxFloat32, math.MaxFloat32)
+		assertOverflow(t, "MultiplyChecked", numeric.MultiplyChecked[float32], math.MaxFloat32, -2)
+	})
+
+	t.Run("float64 infinity is not overflow", func(t *testing.T) {
+		if _, err := numeric.SumChecked(math.Inf(1), 1); err != nil {
+			t.Errorf("SumChecked(+Inf, 1) unexpected error: %v", err)
+		}
+	})
+}
+
+// assertOverflow checks that a checked operation reports ErrOverflow.
+func assertOverflow[T numeric.Number](t *testing.T, name string, op func(a, b T) (T, error), a, b T) {
+	t.Helper()
+	_, err := op(a, b)
+	var overflowErr *numeric.OverflowError[T]
+	if !errors.Is(err, numeric.ErrOverflow) || !errors.As(err, &overflowErr) {
+		t.Errorf("%s(%v, %v) error = %v; want *OverflowError", name, a, b, err)
+		return
+	}
+	if overflowErr.A != a || overflowErr.B != b {
+		t.Errorf("OverflowError operands = (%v, %v); want (%v, %v)", overflowErr.A, overflowErr.B, a, b)
+	}
+}
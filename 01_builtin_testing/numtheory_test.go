@@ -0,0 +1,122 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestIsPrime demonstrates known primes and Miller-Rabin's hard cases.
+func TestIsPrime(t *testing.T) {
+	tests := []struct {
+		name  string
+		n     int
+		prime bool
+	}{
+		{"negative", -7, false},
+		{"zero", 0, false},
+		{"one", 1, false},
+		{"two", 2, true},
+		{"small witness", 37, true},
+		{"square of witness", 1369, false},
+		{"Carmichael number", 561, false},
+		{"strong pseudoprime to bases 2, 3, 5, 7", 3215031751, false},
+		{"strong pseudoprime to the first 11 primes", 3825123056546413051, false},
+		{"Mersenne prime", 2305843009213693951, true},
+		{"largest int64 prime", 9223372036854775783, true},
+		{"MaxInt", math.MaxInt, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := calc.IsPrime(tt.n); got != tt.prime {
+				t.Errorf("IsPrime(%d) = %v; want %v", tt.n, got, tt.prime)
+			}
+		})
+	}
+}
+
+// TestFactorize demonstrates factorisation including large semiprimes.
+func TestFactorize(t *testing.T) {
+	tests := []struct {
+		n       int
+		factors []int
+	}{
+		{1, []int{}},
+		{2, []int{2}},
+		{360, []int{2, 2, 2, 3, 3, 5}},
+		{999983 * 1000003, []int{999983, 1000003}},
+		{3825123056546413051, []int{149491, 747451, 34233211}},
+		{math.MaxInt, []int{7, 7, 73, 127, 337, 92737, 649657}},
+		{9223372036854775783, []int{9223372036854775783}},
+		{1 << 62, func() []int {
+			f := make([]int, 62)
+			for i := range f {
+				f[i] = 2
+			}
+			return f
+		}()},
+	}
+
+	for _, tt := range tests {
+		got, err := calc.Factorize(tt.n)
+		if err != nil {
+			t.Errorf("Factorize(%d) error = %v", tt.n, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.factors) {
+			t.Errorf("Factorize(%d) = %v; want %v", tt.n, got, tt.factors)
+		}
+	}
+
+	if _, err := calc.Factorize(0); !errors.Is(err, calc.ErrDomain) {
+		t.Errorf("Factorize(0) error = %v; want %v", err, calc.ErrDomain)
+	}
+}
+
+// TestBitOperations demonstrates shifts, rotates and popcount at the edges.
+func TestBitOperations(t *testing.T) {
+	if got := calc.PopCount(-1); got != 64 {
+		t.Errorf("PopCount(-1) = %d; want 64", got)
+	}
+	if got := calc.PopCount(0b1011); got != 3 {
+		t.Errorf("PopCount(0b1011) = %d; want 3", got)
+	}
+
+	shifts := []struct {
+		n, s, expected int
+		err            error
+	}{
+		{1, 10, 1024, nil},
+		{-3, 2, -12, nil},
+		{-1, 63, math.MinInt, nil},
+		{1, 62, 1 << 62, nil},
+		{1, 63, 0, calc.ErrOverflow},
+		{3, 62, 0, calc.ErrOverflow},
+		{1, 200, 0, calc.ErrOverflow},
+		{0, 200, 0, nil},
+		{1, -1, 0, calc.ErrDomain},
+	}
+	for _, tt := range shifts {
+		got, err := calc.ShiftLeft(tt.n, tt.s)
+		if !errors.Is(err, tt.err) || got != tt.expected {
+			t.Errorf("ShiftLeft(%d, %d) = %d, %v; want %d, %v", tt.n, tt.s, got, err, tt.expected, tt.err)
+		}
+	}
+
+	if got, _ := calc.ShiftRight(-7, 1); got != -4 {
+		t.Errorf("ShiftRight(-7, 1) = %d; want -4", got)
+	}
+	if got, _ := calc.ShiftRight(math.MinInt, 100); got != -1 {
+		t.Errorf("ShiftRight(MinInt, 100) = %d; want -1", got)
+	}
+	if got := calc.RotateLeft(math.MinInt, 1); got != 1 {
+		t.Errorf("RotateLeft(MinInt, 1) = %d; want 1", got)
+	}
+	if got := calc.RotateRight(1, 1); got != math.MinInt {
+		t.Errorf("RotateRight(1, 1) = %d; want MinInt", got)
+	}
+}
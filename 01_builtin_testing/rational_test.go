@@ -0,0 +1,183 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// mustRational is a test helper that builds a normalised rational.
+func mustRational(t *testing.T, num, den int) calc.Rational {
+	t.Helper()
+	r, err := calc.NewRational(num, den)
+	if err != nil {
+		t.Fatalf("NewRational(%d, %d): %v", num, den, err)
+	}
+	return r
+}
+
+// TestNewRational demonstrates normalisation of sign and common factors.
+func TestNewRational(t *testing.T) {
+	tests := []struct {
+		name     string
+		num, den int
+		expected string
+	}{
+		{"already reduced", 20, 3, "20/3"},
+		{"common factor", 6, 8, "3/4"},
+		{"negative denominator", 3, -4, "-3/4"},
+		{"both negative", -6, -8, "3/4"},
+		{"whole number", 10, 5, "2"},
+		{"zero numerator", 0, -7, "0"},
+		{"zero over min", 0, math.MinInt, "0"},
+		{"min over min", math.MinInt, math.MinInt, "1"},
+		{"min over minus two", math.MinInt, -2, "4611686018427387904"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mustRational(t, tt.num, tt.den)
+			if r.String() != tt.expected {
+				t.Errorf("NewRational(%d, %d) = %s; want %s", tt.num, tt.den, r, tt.expected)
+			}
+			if r.Den() <= 0 {
+				t.Errorf("denominator %d is not positive", r.Den())
+			}
+		})
+	}
+
+	t.Run("zero denominator", func(t *testing.T) {
+		if _, err := calc.NewRational(1, 0); !errors.Is(err, calc.ErrDivisionByZero) {
+			t.Errorf("NewRational(1, 0) error = %v; want ErrDivisionByZero", err)
+		}
+	})
+
+	t.Run("unrepresentable sign flip", func(t *testing.T) {
+		if _, err := calc.NewRational(math.MinInt, -1); !errors.Is(err, calc.ErrOverflow) {
+			t.Errorf("NewRational(MinInt, -1) error = %v; want ErrOverflow", err)
+		}
+	})
+}
+
+// TestRational_Arithmetic mirrors the Calculator operation set.
+func TestRational_Arithmetic(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       func(a, b calc.Rational) (calc.Rational, error)
+		a, b     calc.Rational
+		expected string
+	}{
+		{"add", calc.Rational.Add, mustRational(t, 1, 2), mustRational(t, 1, 3), "5/6"},
+		{"add to whole", calc.Rational.Add, mustRational(t, 1, 2), mustRational(t, 1, 2), "1"},
+		{"subtract", calc.Rational.Subtract, mustRational(t, 1, 2), mustRational(t, 3, 4), "-1/4"},
+		{"multiply", calc.Rational.Multiply, mustRational(t, 2, 3), mustRational(t, 9, 4), "3/2"},
+		{"multiply signs", calc.Rational.Multiply, mustRational(t, -2, 3), mustRational(t, -3, 2), "1"},
+		{"divide keeps remainder", calc.Rational.Divide, calc.RationalFromInt(20), calc.RationalFromInt(3), "20/3"},
+		{"divide by negative", calc.Rational.Divide, mustRational(t, 1, 2), mustRational(t, -1, 4), "-2"},
+		{"zero value is zero", calc.Rational.Add, calc.Rational{}, mustRational(t, 2, 5), "2/5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.op(tt.a, tt.b)
+			if err != nil {
+				t.Fatalf("%s(%s, %s) unexpected error: %v", tt.name, tt.a, tt.b, err)
+			}
+			if result.String() != tt.expected {
+				t.Errorf("%s(%s, %s) = %s; want %s", tt.name, tt.a, tt.b, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestRational_Errors demonstrates division by zero and overflow reporting.
+func TestRational_Errors(t *testing.T) {
+	half := mustRational(t, 1, 2)
+
+	if _, err := half.Divide(calc.Rational{}); !errors.Is(err, calc.ErrDivisionByZero) {
+		t.Errorf("Divide by zero error = %v; want ErrDivisionByZero", err)
+	}
+
+	huge := calc.RationalFromInt(math.MaxInt)
+	if _, err := huge.Add(huge); !errors.Is(err, calc.ErrOverflow) {
+		t.Errorf("MaxInt + MaxInt error = %v; want ErrOverflow", err)
+	}
+
+	// Cross-cancellation keeps this within range even though the naive
+	// numerator product would overflow.
+	a := mustRational(t, math.MaxInt, 3)
+	b := mustRational(t, 3, math.MaxInt)
+	product, err := a.Multiply(b)
+	if err != nil || product.String() != "1" {
+		t.Errorf("(MaxInt/3) * (3/MaxInt) = (%s, %v); want 1", product, err)
+	}
+}
+
+// TestRational_Cmp demonstrates ordering, including values whose
+// cross-products exceed int.
+func TestRational_Cmp(t *testing.T) {
+	tests := []struct {
+		a, b     calc.Rational
+		expected int
+	}{
+		{mustRational(t, 1, 3), mustRational(t, 1, 2), -1},
+		{mustRational(t, 2, 4), mustRational(t, 1, 2), 0},
+		{mustRational(t, -1, 2), mustRational(t, -2, 3), 1},
+		{mustRational(t, math.MaxInt, math.MaxInt-1), mustRational(t, math.MaxInt-1, math.MaxInt-2), -1},
+	}
+
+	for _, tt := range tests {
+		if got := tt.a.Cmp(tt.b); got != tt.expected {
+			t.Errorf("Cmp(%s, %s) = %d; want %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+
+	if !mustRational(t, 1, 2).IsPositive() || mustRational(t, -1, 2).IsPositive() {
+		t.Error("IsPositive disagrees with sign")
+	}
+}
+
+// TestSumRationals demonstrates the slice aggregate.
+func TestSumRationals(t *testing.T) {
+	values := []calc.Rational{mustRational(t, 1, 2), mustRational(t, 1, 3), mustRational(t, 1, 6)}
+
+	total, err := calc.SumRationals(values)
+	if err != nil {
+		t.Fatalf("SumRationals unexpected error: %v", err)
+	}
+	if total != calc.RationalFromInt(1) {
+		t.Errorf("SumRationals = %s; want 1", total)
+	}
+
+	empty, err := calc.SumRationals(nil)
+	if err != nil || empty != calc.RationalFromInt(0) {
+		t.Errorf("SumRationals(nil) = (%s, %v); want 0", empty, err)
+	}
+}
+
+// TestRational_ZeroValue checks that the zero value is the same 0 that the
+// constructors return, so == agrees with Cmp.
+func TestRational_ZeroValue(t *testing.T) {
+	var zero calc.Rational
+	for _, r := range []calc.Rational{mustRational(t, 0, 5), mustRational(t, 0, math.MinInt), calc.RationalFromInt(0)} {
+		if zero != r {
+			t.Errorf("Rational{} != %s", r)
+		}
+	}
+	if zero.Den() != 1 || zero.String() != "0" {
+		t.Errorf("Rational{} = %d/%d; want 0/1", zero.Num(), zero.Den())
+	}
+}
+
+// TestRatBackend runs the rational type through the shared operation set.
+func TestRatBackend(t *testing.T) {
+	runVectors(t, calc.Rat, []backendVector{
+		{"sum", "sum", "1/2", "1/3", "5/6", nil},
+		{"subtract", "subtract", "3", "5", "-2", nil},
+		{"multiply", "multiply", "-3", "4/9", "-4/3", nil},
+		{"divide is exact", "divide", "20", "3", "20/3", nil},
+		{"divide by zero", "divide", "5", "0", "", calc.ErrDivisionByZero},
+	})
+}
@@ -0,0 +1,96 @@
+package calc_test
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestCalculator_Apply demonstrates dispatching operations by name.
+func TestCalculator_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		operands []int
+		expected int
+		err      error
+	}{
+		{"add", "add", []int{2, 3}, 5, nil},
+		{"subtract", "subtract", []int{10, 5}, 5, nil},
+		{"multiply", "multiply", []int{3, 7}, 21, nil},
+		{"divide", "divide", []int{20, 4}, 5, nil},
+		{"mod", "mod", []int{-7, 2}, -1, nil},
+		{"divide by zero", "divide", []int{10, 0}, 0, calc.ErrDivisionByZero},
+		{"checked overflow", "add", []int{math.MaxInt, 1}, 0, calc.ErrOverflow},
+	}
+
+	c := calc.NewCalculator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := c.Apply(tt.op, tt.operands...)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Apply(%q, %v) error = %v; want %v", tt.op, tt.operands, err, tt.err)
+			}
+			if result != tt.expected {
+				t.Errorf("Apply(%q, %v) = %d; want %d", tt.op, tt.operands, result, tt.expected)
+			}
+		})
+	}
+}
+
+// TestCalculator_ApplyErrors checks the messages the BDD steps report.
+func TestCalculator_ApplyErrors(t *testing.T) {
+	var c calc.Calculator // the zero value uses DefaultRegistry
+
+	_, err := c.Apply("sqrt", 2)
+	var unknown *calc.UnknownOperationError
+	if !errors.As(err, &unknown) || err.Error() != "unknown operation: sqrt" {
+		t.Errorf("Apply(\"sqrt\") error = %v; want unknown operation", err)
+	}
+
+	_, err = c.Apply("add", 1)
+	var arity *calc.ArityError
+	if !errors.As(err, &arity) || arity.Want != 2 || arity.Got != 1 {
+		t.Errorf("Apply(\"add\", 1) error = %v; want *ArityError", err)
+	}
+
+	_, err = c.Divide(10, 0)
+	if err == nil || err.Error() != "cannot divide by zero" {
+		t.Errorf("Divide(10, 0) error = %v; want \"cannot divide by zero\"", err)
+	}
+}
+
+// TestRegistry demonstrates registering a custom operation.
+func TestRegistry(t *testing.T) {
+	r := calc.NewRegistry()
+	double := calc.Operation{
+		Name:  "double",
+		Arity: 1,
+		Apply: func(operands ...int) (int, error) { return calc.MultiplyChecked(operands[0], 2) },
+	}
+
+	if err := r.Register(double); err != nil {
+		t.Fatalf("Register unexpected error: %v", err)
+	}
+	if err := r.Register(double); err == nil {
+		t.Error("registering a duplicate name should fail")
+	}
+	if err := r.Register(calc.Operation{Name: "broken", Arity: 1}); err == nil {
+		t.Error("registering an operation without Apply should fail")
+	}
+
+	c := calc.NewCalculatorWithRegistry(r)
+	if result, err := c.Apply("double", 21); err != nil || result != 42 {
+		t.Errorf("Apply(\"double\", 21) = (%d, %v); want 42", result, err)
+	}
+	if _, err := c.Apply("add", 1, 2); err == nil {
+		t.Error("a fresh registry should not contain the default operations")
+	}
+
+	if names := calc.DefaultRegistry.Names(); !reflect.DeepEqual(names, []string{"add", "divide", "factorial", "gcd", "isqrt", "lcm", "mod", "modpow", "multiply", "power", "subtract"}) {
+		t.Errorf("DefaultRegistry.Names() = %v", names)
+	}
+}
@@ -0,0 +1,68 @@
+package calc_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestRPN demonstrates evaluating (2 + 3) * 4 in Reverse Polish Notation.
+func TestRPN(t *testing.T) {
+	r := calc.NewCalculator().NewRPN()
+	r.Push(2)
+	r.Push(3)
+	if _, err := r.Add(); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	r.Push(4)
+	result, err := r.Multiply()
+	if err != nil || result != 20 {
+		t.Fatalf("Multiply() = %d, %v; want 20", result, err)
+	}
+
+	r.Push(10)
+	if err := r.Swap(); err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	if result, _ := r.Divide(); result != 0 {
+		t.Errorf("10 20 divide = %d; want 0", result)
+	}
+	if err := r.Dup(); err != nil {
+		t.Fatalf("Dup() error = %v", err)
+	}
+	if got := r.Stack(); !reflect.DeepEqual(got, []int{0, 0}) {
+		t.Errorf("Stack() = %v; want [0 0]", got)
+	}
+}
+
+// TestRPN_Errors checks that failures are typed and leave the stack intact.
+func TestRPN_Errors(t *testing.T) {
+	r := calc.NewCalculator().NewRPN()
+
+	_, err := r.Pop()
+	var underflow *calc.StackUnderflowError
+	if !errors.As(err, &underflow) || err.Error() != "pop needs 1 values on the stack, have 0" {
+		t.Errorf("Pop() on empty stack error = %v", err)
+	}
+
+	r.Push(1)
+	_, err = r.Subtract()
+	if !errors.As(err, &underflow) || underflow.Op != "subtract" || underflow.Have != 1 {
+		t.Errorf("Subtract() with one value error = %v", err)
+	}
+
+	r.Push(0)
+	if _, err := r.Divide(); !errors.Is(err, calc.ErrDivisionByZero) {
+		t.Errorf("Divide() error = %v; want %v", err, calc.ErrDivisionByZero)
+	}
+	if got := r.Stack(); !reflect.DeepEqual(got, []int{1, 0}) {
+		t.Errorf("Stack() after failed divide = %v; want [1 0]", got)
+	}
+
+	var unknown *calc.UnknownOperationError
+	if _, err := r.Apply("sqrt"); !errors.As(err, &unknown) {
+		t.Errorf("Apply(\"sqrt\") error = %v; want *UnknownOperationError", err)
+	}
+}
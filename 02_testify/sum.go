@@ -1,22 +1,31 @@
 package testify
 
+import (
+	"errors"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
+	"github.com/lirany1/go-testing-framework-examples/domain"
+)
+
 // Sum returns the sum of two integers.
 func Sum(a, b int) int {
-	return a + b
+	return numeric.Sum(a, b)
 }
 
 // Multiply returns the product of two integers.
 func Multiply(a, b int) int {
-	return a * b
+	return numeric.Multiply(a, b)
 }
 
 // Divide returns the result of dividing a by b.
-// Returns an error if b is zero.
+// Returns this package's ErrDivisionByZero if b is zero, and an error
+// wrapping numeric.ErrOverflow for math.MinInt / -1.
 func Divide(a, b int) (int, error) {
-	if b == 0 {
+	quotient, err := numeric.DivideChecked(a, b)
+	if errors.Is(err, numeric.ErrDivisionByZero) {
 		return 0, ErrDivisionByZero
 	}
-	return a / b, nil
+	return quotient, err
 }
 
 // ErrDivisionByZero is returned when attempting to divide by zero.
@@ -29,11 +38,8 @@ func (e Error) Error() string {
 	return string(e)
 }
 
-// User represents a user in the system.
-type User struct {
-	ID   int
-	Name string
-}
+// User is the shared domain user.
+type User = domain.User
 
 // UserRepository defines the interface for user data access.
 type UserRepository interface {
@@ -1,11 +1,15 @@
 package testify
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
+	"github.com/lirany1/go-testing-framework-examples/domain"
 )
 
 // TestSum demonstrates basic assertions with testify/assert.
@@ -76,6 +80,12 @@ func TestDivide(t *testing.T) {
 		assert.Equal(t, ErrDivisionByZero, err)
 		assert.Zero(t, result)
 	})
+
+	t.Run("overflow returns error", func(t *testing.T) {
+		_, err := Divide(math.MinInt, -1)
+
+		assert.ErrorIs(t, err, numeric.ErrOverflow)
+	})
 }
 
 // TestDivide_WithRequire demonstrates require for critical checks.
@@ -176,6 +186,21 @@ func TestUserService_WithMock(t *testing.T) {
 
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("real in-memory repository", func(t *testing.T) {
+		// No mock needed when a real, fast implementation exists
+		repo := domain.NewMemoryUserRepository()
+		user := &User{Name: "Jane Doe", Email: "jane@example.com"}
+		require.NoError(t, repo.SaveUser(user))
+
+		service := NewUserService(repo)
+		name, err := service.GetUserName(user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Doe", name)
+
+		_, err = service.GetUserName(user.ID + 1)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+	})
 }
 
 // TestMock_AdvancedUsage demonstrates advanced mock features.
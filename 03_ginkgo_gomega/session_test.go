@@ -0,0 +1,225 @@
+package ginkgo_gomega_test
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+	ginkgo_gomega "github.com/lirany1/go-testing-framework-examples/03_ginkgo_gomega"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Session specs exercise the recording mode of the shared Calculator.
+var _ = Describe("Calculator Session", func() {
+	var session *calc.Session
+
+	BeforeEach(func() {
+		session = ginkgo_gomega.NewCalculator().NewSession()
+	})
+
+	Describe("recording operations", func() {
+		It("should start empty", func() {
+			Expect(session.History()).To(BeEmpty())
+			Expect(session.Value()).To(Equal(0))
+			Expect(session.CanUndo()).To(BeFalse())
+			Expect(session.CanRedo()).To(BeFalse())
+		})
+
+		It("should record each operation with its operands and result", func() {
+			Expect(session.Apply("add", 2, 3)).To(Equal(5))
+			Expect(session.Apply("multiply", 5, 4)).To(Equal(20))
+
+			Expect(session.History()).To(HaveLen(2))
+			entry := session.History()[1]
+			Expect(entry.Operation).To(Equal("multiply"))
+			Expect(entry.Operands).To(Equal([]int{5, 4}))
+			Expect(entry.Result).To(Equal(20))
+			Expect(entry.Error).To(BeEmpty())
+			Expect(session.Value()).To(Equal(20))
+		})
+
+		It("should record failed operations with their error", func() {
+			_, err := session.Apply("divide", 10, 0)
+			Expect(err).To(MatchError(calc.ErrDivisionByZero))
+
+			Expect(session.History()).To(HaveLen(1))
+			Expect(session.History()[0].Error).To(Equal("cannot divide by zero"))
+
+			_, err = session.Value()
+			Expect(err).To(MatchError(calc.ErrDivisionByZero))
+		})
+
+		It("should not let callers change the recorded history", func() {
+			operands := []int{1, 2}
+			_, _ = session.Apply("add", operands...)
+			operands[0] = 99
+
+			history := session.History()
+			history[0].Operands[1] = 99
+
+			Expect(session.History()[0].Operands).To(Equal([]int{1, 2}))
+		})
+	})
+
+	Describe("Undo and Redo", func() {
+		BeforeEach(func() {
+			_, _ = session.Apply("add", 2, 3)
+			_, _ = session.Apply("multiply", 5, 4)
+		})
+
+		It("should undo the most recent operation", func() {
+			Expect(session.Undo()).To(Succeed())
+			Expect(session.Value()).To(Equal(5))
+			Expect(session.History()).To(HaveLen(1))
+		})
+
+		It("should redo what was undone", func() {
+			Expect(session.Undo()).To(Succeed())
+			Expect(session.Redo()).To(Succeed())
+			Expect(session.Value()).To(Equal(20))
+			Expect(session.History()).To(HaveLen(2))
+		})
+
+		It("should fail when there is nothing to undo", func() {
+			Expect(session.Undo()).To(Succeed())
+			Expect(session.Undo()).To(Succeed())
+			Expect(session.Undo()).To(MatchError(calc.ErrNothingToUndo))
+			Expect(session.Value()).To(Equal(0))
+		})
+
+		It("should fail when there is nothing to redo", func() {
+			Expect(session.Redo()).To(MatchError(calc.ErrNothingToRedo))
+		})
+
+		It("should discard the redo stack when a new operation is applied", func() {
+			Expect(session.Undo()).To(Succeed())
+			_, _ = session.Apply("subtract", 5, 1)
+
+			Expect(session.CanRedo()).To(BeFalse())
+			Expect(session.Redo()).To(MatchError(calc.ErrNothingToRedo))
+			Expect(session.Value()).To(Equal(4))
+		})
+
+		It("should restore a recorded error on redo", func() {
+			_, _ = session.Apply("divide", 1, 0)
+			Expect(session.Undo()).To(Succeed())
+			Expect(session.Redo()).To(Succeed())
+
+			_, err := session.Value()
+			Expect(err).To(MatchError(calc.ErrDivisionByZero))
+		})
+	})
+
+	Describe("serialising the history", func() {
+		It("should marshal to JSON", func() {
+			_, _ = session.Apply("add", 2, 3)
+			_, _ = session.Apply("divide", 5, 0)
+
+			data, err := json.Marshal(session.History())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data).To(MatchJSON(`[
+				{"operation": "add", "operands": [2, 3], "result": 5},
+				{"operation": "divide", "operands": [5, 0], "result": 0, "error": "cannot divide by zero"}
+			]`))
+		})
+
+		It("should round-trip through JSON", func() {
+			_, _ = session.Apply("subtract", 10, 4)
+
+			data, err := json.Marshal(session.History())
+			Expect(err).NotTo(HaveOccurred())
+
+			var restored []calc.Entry
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+			Expect(restored).To(HaveLen(1))
+			Expect(restored[0].Operation).To(Equal("subtract"))
+			Expect(restored[0].Operands).To(Equal([]int{10, 4}))
+			Expect(restored[0].Result).To(Equal(6))
+		})
+	})
+
+	Describe("memory registers", func() {
+		It("should start with an empty memory", func() {
+			Expect(session.Memory()).To(Equal(0))
+		})
+
+		It("should add and subtract the current value with M+ and M-", func() {
+			_, _ = session.Apply("add", 2, 3)
+			Expect(session.MemoryAdd()).To(Succeed())
+			Expect(session.MemoryAdd()).To(Succeed())
+			Expect(session.Memory()).To(Equal(10))
+
+			_, _ = session.Apply("multiply", 2, 2)
+			Expect(session.MemorySubtract()).To(Succeed())
+			Expect(session.Memory()).To(Equal(6))
+		})
+
+		It("should recall memory as the current value", func() {
+			_, _ = session.Apply("add", 4, 4)
+			Expect(session.MemoryAdd()).To(Succeed())
+			_, _ = session.Apply("subtract", 1, 1)
+
+			Expect(session.MemoryRecall()).To(Equal(8))
+			Expect(session.Value()).To(Equal(8))
+			Expect(session.History()[2].Operation).To(Equal("recall"))
+		})
+
+		It("should reset memory with MC", func() {
+			_, _ = session.Apply("add", 1, 2)
+			Expect(session.MemoryAdd()).To(Succeed())
+			session.MemoryClear()
+			Expect(session.Memory()).To(Equal(0))
+		})
+
+		Context("when an error is displayed", func() {
+			BeforeEach(func() {
+				_, _ = session.Apply("add", 3, 4)
+				Expect(session.MemoryAdd()).To(Succeed())
+				_, _ = session.Apply("divide", 1, 0)
+			})
+
+			It("should refuse M+ and M- and leave memory unchanged", func() {
+				Expect(session.MemoryAdd()).To(MatchError(calc.ErrErrorDisplayed))
+				Expect(session.MemorySubtract()).To(MatchError(calc.ErrErrorDisplayed))
+				Expect(session.Memory()).To(Equal(7))
+			})
+
+			It("should clear the error on MR", func() {
+				session.MemoryRecall()
+				Expect(session.Value()).To(Equal(7))
+			})
+
+			It("should still allow MC", func() {
+				session.MemoryClear()
+				Expect(session.Memory()).To(Equal(0))
+			})
+		})
+
+		It("should leave memory unchanged on overflow", func() {
+			_, _ = session.Apply("add", math.MaxInt, 0)
+			Expect(session.MemoryAdd()).To(Succeed())
+			Expect(session.MemoryAdd()).To(MatchError(calc.ErrOverflow))
+			Expect(session.Memory()).To(Equal(math.MaxInt))
+		})
+
+		It("should not be affected by undo and redo", func() {
+			_, _ = session.Apply("add", 2, 2)
+			Expect(session.MemoryAdd()).To(Succeed())
+			Expect(session.Undo()).To(Succeed())
+			Expect(session.Memory()).To(Equal(4))
+		})
+
+		It("should dispatch keys by name", func() {
+			_, _ = session.Apply("add", 5, 0)
+			Expect(session.PressMemory("M+")).To(Succeed())
+			Expect(session.PressMemory("MR")).To(Succeed())
+			Expect(session.Value()).To(Equal(5))
+			Expect(session.PressMemory("MC")).To(Succeed())
+			Expect(session.Memory()).To(Equal(0))
+
+			err := session.PressMemory("M*")
+			Expect(err).To(BeAssignableToTypeOf(&calc.UnknownOperationError{}))
+		})
+	})
+})
@@ -0,0 +1,135 @@
+package ginkgo_gomega
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// ErrEmptyInput is returned by statistics that are undefined for no values.
+var ErrEmptyInput = Error("cannot compute statistics of empty input")
+
+// ErrInvalidPercentile is returned when a percentile is outside [0, 100].
+var ErrInvalidPercentile = Error("percentile must be between 0 and 100")
+
+// Mean returns the arithmetic mean of a slice of integers. The sum is
+// exact, so values near the int limits do not wrap around.
+func (c *Calculator) Mean(numbers []int) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptyInput
+	}
+	sum := new(big.Int)
+	for _, n := range numbers {
+		sum.Add(sum, big.NewInt(int64(n)))
+	}
+	mean := new(big.Float).SetInt(sum)
+	mean.Quo(mean, new(big.Float).SetInt64(int64(len(numbers))))
+	result, _ := mean.Float64()
+	return result, nil
+}
+
+// Median returns the middle value of a slice of integers, or the mean of
+// the two middle values when the length is even.
+func (c *Calculator) Median(numbers []int) (float64, error) {
+	return c.Percentile(numbers, 50)
+}
+
+// Mode returns the most frequent values in ascending order.
+// Every value is returned when all occur equally often.
+func (c *Calculator) Mode(numbers []int) ([]int, error) {
+	if len(numbers) == 0 {
+		return nil, ErrEmptyInput
+	}
+
+	counts := make(map[int]int)
+	best := 0
+	for _, n := range numbers {
+		counts[n]++
+		if counts[n] > best {
+			best = counts[n]
+		}
+	}
+
+	var modes []int
+	for n, count := range counts {
+		if count == best {
+			modes = append(modes, n)
+		}
+	}
+	sort.Ints(modes)
+	return modes, nil
+}
+
+// Variance returns the population variance of a slice of integers.
+func (c *Calculator) Variance(numbers []int) (float64, error) {
+	mean, err := c.Mean(numbers)
+	if err != nil {
+		return 0, err
+	}
+
+	var squares float64
+	for _, n := range numbers {
+		d := float64(n) - mean
+		squares += d * d
+	}
+	return squares / float64(len(numbers)), nil
+}
+
+// StdDev returns the population standard deviation of a slice of integers.
+func (c *Calculator) StdDev(numbers []int) (float64, error) {
+	variance, err := c.Variance(numbers)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(variance), nil
+}
+
+// Min returns the smallest value in a slice of integers.
+func (c *Calculator) Min(numbers []int) (int, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptyInput
+	}
+	result := numbers[0]
+	for _, n := range numbers[1:] {
+		if n < result {
+			result = n
+		}
+	}
+	return result, nil
+}
+
+// Max returns the largest value in a slice of integers.
+func (c *Calculator) Max(numbers []int) (int, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptyInput
+	}
+	result := numbers[0]
+	for _, n := range numbers[1:] {
+		if n > result {
+			result = n
+		}
+	}
+	return result, nil
+}
+
+// Percentile returns the p-th percentile (0-100) of a slice of integers,
+// interpolating linearly between the closest ranks. Percentile 0 is the
+// minimum, 50 the median and 100 the maximum. The input is not modified.
+func (c *Calculator) Percentile(numbers []int, p float64) (float64, error) {
+	if len(numbers) == 0 {
+		return 0, ErrEmptyInput
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, ErrInvalidPercentile
+	}
+
+	sorted := append([]int(nil), numbers...)
+	sort.Ints(sorted)
+
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := int(math.Ceil(rank))
+	fraction := rank - float64(lower)
+	a, b := float64(sorted[lower]), float64(sorted[upper])
+	return a + fraction*(b-a), nil // in float64, as b-a can overflow int
+}
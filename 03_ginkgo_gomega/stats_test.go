@@ -0,0 +1,143 @@
+package ginkgo_gomega_test
+
+import (
+	"math"
+
+	ginkgo_gomega "github.com/lirany1/go-testing-framework-examples/03_ginkgo_gomega"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// Statistics specs share one data set and check each aggregate against
+// values worked out by hand.
+var _ = Describe("Calculator Statistics", func() {
+	var (
+		calc    *ginkgo_gomega.Calculator
+		numbers []int
+	)
+
+	BeforeEach(func() {
+		calc = ginkgo_gomega.NewCalculator()
+		numbers = []int{2, 4, 4, 4, 5, 5, 7, 9}
+	})
+
+	Describe("Mean", func() {
+		It("should divide the sum by the count", func() {
+			Expect(calc.Mean(numbers)).To(Equal(5.0))
+		})
+
+		It("should keep the fractional part", func() {
+			Expect(calc.Mean([]int{1, 2})).To(Equal(1.5))
+		})
+
+		It("should not wrap around at the int limits", func() {
+			Expect(calc.Mean([]int{math.MaxInt, 1})).To(BeNumerically("~", 4.611686018427388e18, 1e3))
+			Expect(calc.Mean([]int{math.MinInt, math.MinInt})).To(Equal(float64(math.MinInt)))
+		})
+	})
+
+	Describe("Median", func() {
+		It("should average the middle pair for even lengths", func() {
+			Expect(calc.Median(numbers)).To(Equal(4.5))
+		})
+
+		It("should not overflow when the middle pair is far apart", func() {
+			Expect(calc.Median([]int{math.MinInt, math.MaxInt})).To(BeNumerically("~", -0.5, 1))
+		})
+
+		It("should pick the middle value for odd lengths", func() {
+			Expect(calc.Median([]int{9, 1, 5})).To(Equal(5.0))
+		})
+
+		It("should not reorder the input", func() {
+			input := []int{3, 1, 2}
+			_, _ = calc.Median(input)
+			Expect(input).To(Equal([]int{3, 1, 2}))
+		})
+	})
+
+	Describe("Mode", func() {
+		It("should return the most frequent value", func() {
+			Expect(calc.Mode(numbers)).To(Equal([]int{4}))
+		})
+
+		It("should return every value tied for most frequent", func() {
+			Expect(calc.Mode([]int{3, 1, 3, 1, 2})).To(Equal([]int{1, 3}))
+		})
+	})
+
+	Describe("Variance and standard deviation", func() {
+		It("should compute the population variance", func() {
+			Expect(calc.Variance(numbers)).To(Equal(4.0))
+		})
+
+		It("should compute the population standard deviation", func() {
+			Expect(calc.StdDev(numbers)).To(Equal(2.0))
+		})
+
+		It("should use a mean that has not wrapped around", func() {
+			Expect(calc.Variance([]int{math.MaxInt, math.MaxInt})).To(Equal(0.0))
+			Expect(calc.StdDev([]int{math.MaxInt, math.MaxInt})).To(Equal(0.0))
+		})
+
+		It("should be zero for constant input", func() {
+			Expect(calc.StdDev([]int{7, 7, 7})).To(BeZero())
+		})
+	})
+
+	Describe("Min and Max", func() {
+		It("should find the extremes", func() {
+			Expect(calc.Min(numbers)).To(Equal(2))
+			Expect(calc.Max(numbers)).To(Equal(9))
+		})
+
+		It("should handle negative numbers", func() {
+			Expect(calc.Min([]int{-3, 0, -7})).To(Equal(-7))
+			Expect(calc.Max([]int{-3, -1, -7})).To(Equal(-1))
+		})
+	})
+
+	Describe("Percentile", func() {
+		DescribeTable("interpolating between ranks",
+			func(p float64, expected float64) {
+				Expect(calc.Percentile([]int{10, 20, 30, 40, 50}, p)).To(BeNumerically("~", expected, 1e-9))
+			},
+			Entry("minimum", 0.0, 10.0),
+			Entry("lower quartile", 25.0, 20.0),
+			Entry("median", 50.0, 30.0),
+			Entry("between ranks", 90.0, 46.0),
+			Entry("maximum", 100.0, 50.0),
+		)
+
+		It("should reject percentiles outside 0-100", func() {
+			_, err := calc.Percentile(numbers, 101)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrInvalidPercentile))
+
+			_, err = calc.Percentile(numbers, -1)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrInvalidPercentile))
+		})
+	})
+
+	Context("when the input is empty", func() {
+		It("should return ErrEmptyInput instead of panicking", func() {
+			empty := []int{}
+
+			_, err := calc.Mean(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.Median(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.Mode(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.Variance(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.StdDev(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.Min(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.Max(empty)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+			_, err = calc.Percentile(nil, 50)
+			Expect(err).To(MatchError(ginkgo_gomega.ErrEmptyInput))
+		})
+	})
+})
@@ -4,34 +4,28 @@ import (
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
 )
 
 // Sum returns the sum of two integers.
 func Sum(a, b int) int {
-	return a + b
+	return numeric.Sum(a, b)
 }
 
 // Multiply returns the product of two integers.
 func Multiply(a, b int) int {
-	return a * b
+	return numeric.Multiply(a, b)
 }
 
 // Divide divides two integers.
 func Divide(a, b int) (int, error) {
-	if b == 0 {
-		return 0, ErrDivisionByZero
-	}
-	return a / b, nil
+	return numeric.DivideChecked(a, b)
 }
 
 // ErrDivisionByZero is returned when dividing by zero.
-type errDivisionByZero struct{}
-
-func (e errDivisionByZero) Error() string {
-	return "division by zero"
-}
-
-var ErrDivisionByZero = errDivisionByZero{}
+var ErrDivisionByZero = calc.ErrDivisionByZero
 
 // TestSum demonstrates GoConvey's BDD-style syntax.
 // Run with: go test
@@ -138,7 +132,7 @@ func TestDivide(t *testing.T) {
 
 			Convey("error message should be descriptive", func() {
 				_, err := Divide(10, 0)
-				So(err.Error(), ShouldContainSubstring, "division by zero")
+				So(err.Error(), ShouldContainSubstring, "divide by zero")
 			})
 		})
 	})
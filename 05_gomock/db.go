@@ -2,12 +2,10 @@ package gomock
 
 //go:generate mockgen -source=db.go -destination=mock_db.go -package=gomock
 
-// User represents a user in the system.
-type User struct {
-	ID    int
-	Name  string
-	Email string
-}
+import "github.com/lirany1/go-testing-framework-examples/domain"
+
+// User is the shared domain user.
+type User = domain.User
 
 // UserRepository defines the interface for user data operations.
 type UserRepository interface {
@@ -26,12 +24,15 @@ type UserRepository interface {
 
 // UserService provides business logic for user operations.
 type UserService struct {
-	repo UserRepository
+	repo  UserRepository
+	rules []domain.Rule
 }
 
-// NewUserService creates a new UserService.
-func NewUserService(repo UserRepository) *UserService {
-	return &UserService{repo: repo}
+// NewUserService creates a new UserService. The optional rules, such as
+// domain.BlockedDomains, are checked by CreateUser in addition to the
+// built-in name and email validation.
+func NewUserService(repo UserRepository, rules ...domain.Rule) *UserService {
+	return &UserService{repo: repo, rules: rules}
 }
 
 // GetUserName retrieves a user's name by ID.
@@ -43,11 +44,14 @@ func (s *UserService) GetUserName(id int) (string, error) {
 	return user.Name, nil
 }
 
-// CreateUser creates a new user.
+// CreateUser validates and saves a new user. The name is trimmed and the
+// email normalised with domain.NormalizeEmail. Invalid input is reported as
+// a *domain.ValidationError listing every failing field, and nothing is
+// saved.
 func (s *UserService) CreateUser(name, email string) error {
-	user := &User{
-		Name:  name,
-		Email: email,
+	user, err := domain.NewUser(name, email, s.rules...)
+	if err != nil {
+		return err
 	}
 	return s.repo.SaveUser(user)
 }
@@ -2,9 +2,12 @@ package gomock
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
 )
 
 // TestUserService_GetUserName demonstrates basic mock usage.
@@ -100,6 +103,112 @@ func TestUserService_CreateUser_WithMatcher(t *testing.T) {
 	}
 }
 
+// TestUserService_CreateUser_Normalizes checks that SaveUser receives the
+// trimmed name and the email with its domain lowercased.
+func TestUserService_CreateUser_Normalizes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	mockRepo.EXPECT().SaveUser(&User{Name: "Carol", Email: "Carol.Smith@example.com"}).Return(nil)
+
+	service := NewUserService(mockRepo)
+	if err := service.CreateUser("  Carol ", " Carol.Smith@Example.COM "); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+}
+
+// TestUserService_CreateUser_Invalid demonstrates Times(0): invalid input
+// must never reach the repository.
+func TestUserService_CreateUser_Invalid(t *testing.T) {
+	tests := []struct {
+		name, user, email string
+		fields            map[string]error // failing field -> reason
+	}{
+		{"missing name", "", "dave@example.com", map[string]error{"name": domain.ErrRequired}},
+		{"blank name", "   ", "dave@example.com", map[string]error{"name": domain.ErrRequired}},
+		{"name too long", strings.Repeat("x", domain.MaxNameLength+1), "dave@example.com", map[string]error{"name": domain.ErrTooLong}},
+		{"missing email", "Dave", "", map[string]error{"email": domain.ErrRequired}},
+		{"invalid email", "Dave", "dave@localhost", map[string]error{"email": domain.ErrInvalidEmail}},
+		{"blocked domain", "Dave", "dave@Mailinator.com", map[string]error{"email": domain.ErrBlockedDomain}},
+		{"blocked subdomain", "Dave", "dave@eu.mailinator.com", map[string]error{"email": domain.ErrBlockedDomain}},
+		{"every field", "", "not-an-email", map[string]error{"name": domain.ErrRequired, "email": domain.ErrInvalidEmail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockRepo := NewMockUserRepository(ctrl)
+			mockRepo.EXPECT().SaveUser(gomock.Any()).Times(0)
+
+			service := NewUserService(mockRepo, domain.BlockedDomains("mailinator.com"))
+			err := service.CreateUser(tt.user, tt.email)
+
+			var ve *domain.ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("Expected *domain.ValidationError, got %v", err)
+			}
+			if len(ve.Fields) != len(tt.fields) {
+				t.Errorf("Expected %d failing fields, got %v", len(tt.fields), err)
+			}
+			for field, want := range tt.fields {
+				if fe := ve.Field(field); fe == nil || !errors.Is(fe, want) {
+					t.Errorf("Field(%q) = %v; want %v", field, fe, want)
+				}
+			}
+		})
+	}
+}
+
+// TestUserService_CreateUser_SaveError checks that repository errors are
+// returned unchanged once validation passes.
+func TestUserService_CreateUser_SaveError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := NewMockUserRepository(ctrl)
+	saveErr := errors.New("disk full")
+	mockRepo.EXPECT().SaveUser(gomock.Any()).Return(saveErr)
+
+	service := NewUserService(mockRepo, domain.BlockedDomains("mailinator.com"))
+	if err := service.CreateUser("Erin", "erin@example.com"); !errors.Is(err, saveErr) {
+		t.Errorf("Expected %v, got %v", saveErr, err)
+	}
+}
+
+// The domain repositories are drop-in replacements for the mock.
+var (
+	_ UserRepository = (*domain.MemoryUserRepository)(nil)
+	_ UserRepository = (*domain.FileUserRepository)(nil)
+)
+
+// TestUserService_MemoryRepository runs the service against the real
+// in-memory repository instead of a mock.
+func TestUserService_MemoryRepository(t *testing.T) {
+	repo := domain.NewMemoryUserRepository()
+	service := NewUserService(repo)
+
+	for _, name := range []string{"Alice", "Bob"} {
+		if err := service.CreateUser(name, strings.ToLower(name)+"@example.com"); err != nil {
+			t.Fatalf("CreateUser(%q) error = %v", name, err)
+		}
+	}
+
+	name, err := service.GetUserName(2)
+	if err != nil || name != "Bob" {
+		t.Errorf("GetUserName(2) = %q, %v; want %q, nil", name, err, "Bob")
+	}
+	if err := service.RemoveUser(1); err != nil {
+		t.Errorf("RemoveUser(1) error = %v", err)
+	}
+	names, err := service.GetAllUserNames()
+	if err != nil || len(names) != 1 || names[0] != "Bob" {
+		t.Errorf("GetAllUserNames() = %v, %v; want [Bob], nil", names, err)
+	}
+}
+
 // TestUserService_RemoveUser demonstrates mocking DeleteUser.
 func TestUserService_RemoveUser(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -1,47 +1,30 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/cucumber/godog"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
 )
 
 // calculatorContext holds the state for calculator scenarios.
 type calculatorContext struct {
-	calculator Calculator
+	calculator *calc.Calculator
+	session    *calc.Session
 	numbers    []int
 	result     int
 	err        error
 }
 
-// Calculator provides arithmetic operations.
-type Calculator struct{}
-
-func (c *Calculator) Add(a, b int) int {
-	return a + b
-}
-
-func (c *Calculator) Subtract(a, b int) int {
-	return a - b
-}
-
-func (c *Calculator) Multiply(a, b int) int {
-	return a * b
-}
-
-func (c *Calculator) Divide(a, b int) (int, error) {
-	if b == 0 {
-		return 0, fmt.Errorf("cannot divide by zero")
-	}
-	return a / b, nil
-}
-
 // Step definitions
 
 func (cc *calculatorContext) aCalculator() error {
-	cc.calculator = Calculator{}
+	cc.calculator = calc.NewCalculator()
+	cc.session = cc.calculator.NewSession()
 	cc.numbers = []int{}
 	cc.result = 0
 	cc.err = nil
@@ -53,51 +36,47 @@ func (cc *calculatorContext) iHaveEnteredIntoTheCalculator(number int) error {
 	return nil
 }
 
-func (cc *calculatorContext) iPressAdd() error {
-	if len(cc.numbers) < 2 {
-		return fmt.Errorf("need at least 2 numbers")
-	}
-	cc.result = cc.calculator.Add(cc.numbers[0], cc.numbers[1])
-	return nil
-}
+// iPressOperation dispatches to the shared operation registry by name, so
+// any registered operation can be used in a feature file. The entered
+// numbers are consumed, so the next operation starts from fresh input.
+func (cc *calculatorContext) iPressOperation(operation string) error {
+	result, err := cc.session.Apply(operation, cc.numbers...)
+	cc.numbers = []int{}
 
-func (cc *calculatorContext) iPressSubtract() error {
-	if len(cc.numbers) < 2 {
-		return fmt.Errorf("need at least 2 numbers")
+	var unknown *calc.UnknownOperationError
+	var arity *calc.ArityError
+	if errors.As(err, &unknown) || errors.As(err, &arity) {
+		// A badly written scenario, not a calculator result.
+		return err
 	}
-	cc.result = cc.calculator.Subtract(cc.numbers[0], cc.numbers[1])
+
+	cc.result, cc.err = result, err
 	return nil
 }
 
-func (cc *calculatorContext) iPressMultiply() error {
-	if len(cc.numbers) < 2 {
-		return fmt.Errorf("need at least 2 numbers")
+// iPressMemoryKey handles the M+, M-, MR and MC keys. M+ and M- are ignored
+// while an error is on the screen, as on a physical calculator.
+func (cc *calculatorContext) iPressMemoryKey(key string) error {
+	err := cc.session.PressMemory(key)
+	if err != nil && !errors.Is(err, calc.ErrErrorDisplayed) {
+		return err
 	}
-	cc.result = cc.calculator.Multiply(cc.numbers[0], cc.numbers[1])
+	cc.result, cc.err = cc.session.Value()
 	return nil
 }
 
-func (cc *calculatorContext) iPressDivide() error {
-	if len(cc.numbers) < 2 {
-		return fmt.Errorf("need at least 2 numbers")
+func (cc *calculatorContext) theMemoryShouldHold(expected int) error {
+	if memory := cc.session.Memory(); memory != expected {
+		return fmt.Errorf("expected memory %d, got %d", expected, memory)
 	}
-	cc.result, cc.err = cc.calculator.Divide(cc.numbers[0], cc.numbers[1])
 	return nil
 }
 
-func (cc *calculatorContext) iPressOperation(operation string) error {
-	switch operation {
-	case "add":
-		return cc.iPressAdd()
-	case "subtract":
-		return cc.iPressSubtract()
-	case "multiply":
-		return cc.iPressMultiply()
-	case "divide":
-		return cc.iPressDivide()
-	default:
-		return fmt.Errorf("unknown operation: %s", operation)
+func (cc *calculatorContext) iShouldSeeNoError() error {
+	if cc.err != nil {
+		return fmt.Errorf("expected no error, got '%s'", cc.err.Error())
 	}
+	return nil
 }
 
 func (cc *calculatorContext) theResultShouldBeOnTheScreen(expected int) error {
@@ -123,14 +102,14 @@ func InitializeScenario(sc *godog.ScenarioContext) {
 
 	// Register step definitions
 	sc.Step(`^a calculator$`, cc.aCalculator)
-	sc.Step(`^I have entered (\d+) into the calculator$`, cc.iHaveEnteredIntoTheCalculator)
-	sc.Step(`^I press add$`, cc.iPressAdd)
-	sc.Step(`^I press subtract$`, cc.iPressSubtract)
-	sc.Step(`^I press multiply$`, cc.iPressMultiply)
-	sc.Step(`^I press divide$`, cc.iPressDivide)
-	sc.Step(`^I press (.+)$`, cc.iPressOperation)
-	sc.Step(`^the result should be (\d+) on the screen$`, cc.theResultShouldBeOnTheScreen)
+	sc.Step(`^I have entered (-?\d+) into the calculator$`, cc.iHaveEnteredIntoTheCalculator)
+	// Memory keys come first: MR and MC are also valid operation names.
+	sc.Step(`^I press (M\+|M-|MR|MC)$`, cc.iPressMemoryKey)
+	sc.Step(`^I press ([A-Za-z_][A-Za-z0-9_]*)$`, cc.iPressOperation)
+	sc.Step(`^the result should be (-?\d+) on the screen$`, cc.theResultShouldBeOnTheScreen)
 	sc.Step(`^I should see an error message "([^"]*)"$`, cc.iShouldSeeAnErrorMessage)
+	sc.Step(`^I should see no error$`, cc.iShouldSeeNoError)
+	sc.Step(`^the memory should hold (-?\d+)$`, cc.theMemoryShouldHold)
 }
 
 // TestFeatures runs the Godog test suite.
@@ -0,0 +1,43 @@
+Feature: Math Pack Operations
+  As a user of the calculator
+  I want extra operations such as power and gcd
+  So that I can do number theory without leaving the calculator
+
+  Background:
+    Given a calculator
+
+  Scenario: Raise a number to a power
+    Given I have entered 2 into the calculator
+    And I have entered 10 into the calculator
+    When I press power
+    Then the result should be 1024 on the screen
+
+  Scenario: Factorial takes a single number
+    Given I have entered 5 into the calculator
+    When I press factorial
+    Then the result should be 120 on the screen
+
+  Scenario: Modular exponentiation takes three numbers
+    Given I have entered 4 into the calculator
+    And I have entered 13 into the calculator
+    And I have entered 497 into the calculator
+    When I press modpow
+    Then the result should be 445 on the screen
+
+  Scenario: Negative exponents are rejected
+    Given I have entered 2 into the calculator
+    And I have entered -1 into the calculator
+    When I press power
+    Then I should see an error message "operand out of domain"
+
+  Scenario Outline: Number theory operations
+    Given I have entered <first> into the calculator
+    And I have entered <second> into the calculator
+    When I press <operation>
+    Then the result should be <result> on the screen
+
+    Examples:
+      | first | second | operation | result |
+      | 12    | 18     | gcd       | 6      |
+      | 4     | 6      | lcm       | 12     |
+      | -2    | 3      | power     | -8     |
@@ -0,0 +1,54 @@
+Feature: Calculator Memory
+  As a user of the calculator
+  I want to keep intermediate results in memory
+  So that I can reuse them in later calculations
+
+  Background:
+    Given a calculator
+
+  Scenario: Store a result and recall it later
+    Given I have entered 2 into the calculator
+    And I have entered 3 into the calculator
+    When I press add
+    And I press M+
+    And I have entered 4 into the calculator
+    And I have entered 6 into the calculator
+    And I press multiply
+    Then the result should be 24 on the screen
+    When I press MR
+    Then the result should be 5 on the screen
+    And the memory should hold 5
+
+  Scenario: Subtract a result from memory
+    Given I have entered 10 into the calculator
+    And I have entered 5 into the calculator
+    When I press add
+    And I press M+
+    And I have entered 2 into the calculator
+    And I have entered 2 into the calculator
+    And I press multiply
+    And I press M-
+    Then the memory should hold 11
+
+  Scenario: Clear the memory
+    Given I have entered 7 into the calculator
+    And I have entered 1 into the calculator
+    When I press subtract
+    And I press M+
+    And I press MC
+    Then the memory should hold 0
+
+  Scenario: Memory keys after division by zero
+    Given I have entered 3 into the calculator
+    And I have entered 4 into the calculator
+    When I press add
+    And I press M+
+    And I have entered 10 into the calculator
+    And I have entered 0 into the calculator
+    And I press divide
+    And I press M+
+    Then I should see an error message "cannot divide by zero"
+    And the memory should hold 7
+    When I press MR
+    Then I should see no error
+    And the result should be 7 on the screen
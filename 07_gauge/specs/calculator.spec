@@ -35,6 +35,12 @@
 * Press "divide" button
 * Should see error "cannot divide by zero"
 
+## Factorial of a single number
+* Initialize calculator
+* Enter number "5"
+* Press "factorial" button
+* Result should be "120"
+
 ## Data-driven calculations
    |operation |first|second|result|
    |----------|-----|------|------|
@@ -42,5 +48,8 @@
    |subtract  |10   |5     |5     |
    |multiply  |3    |7     |21    |
    |divide    |20   |4     |5     |
+   |power     |2    |10    |1024  |
+   |gcd       |12   |18    |6     |
+   |lcm       |4    |6     |12    |
 
 * Perform calculation with data table
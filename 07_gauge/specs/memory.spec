@@ -0,0 +1,65 @@
+# Calculator Memory
+
+## Store a result and recall it
+* Initialize calculator
+* Enter number "2"
+* Enter number "3"
+* Press "add" button
+* Press memory "M+"
+* Enter number "4"
+* Enter number "6"
+* Press "multiply" button
+* Result should be "24"
+* Press memory "MR"
+* Result should be "5"
+* Memory should be "5"
+
+## Subtract a result from memory
+* Initialize calculator
+* Enter number "10"
+* Enter number "5"
+* Press "add" button
+* Press memory "M+"
+* Enter number "2"
+* Enter number "2"
+* Press "multiply" button
+* Press memory "M-"
+* Memory should be "11"
+
+## Clear the memory
+* Initialize calculator
+* Enter number "7"
+* Enter number "1"
+* Press "subtract" button
+* Press memory "M+"
+* Press memory "MC"
+* Memory should be "0"
+
+## Memory keys after division by zero
+* Initialize calculator
+* Enter number "3"
+* Enter number "4"
+* Press "add" button
+* Press memory "M+"
+* Enter number "10"
+* Enter number "0"
+* Press "divide" button
+* Press memory "M+"
+* Should see error "cannot divide by zero"
+* Memory should be "7"
+* Press memory "MR"
+* Result should be "7"
+
+## Operations after division by zero
+* Initialize calculator
+* Enter number "10"
+* Enter number "0"
+* Press "divide" button
+* Should see error "cannot divide by zero"
+* Enter number "6"
+* Enter number "7"
+* Press "multiply" button
+* Should see no error
+* Result should be "42"
+* Press memory "M+"
+* Memory should be "42"
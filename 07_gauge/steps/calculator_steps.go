@@ -4,15 +4,18 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/getgauge-contrib/gauge-go/gauge"
 	"github.com/getgauge-contrib/gauge-go/models"
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
 	"github.com/lirany1/go-testing-framework-examples/07_gauge/testsuit"
 )
 
 var _ = gauge.Step("Initialize calculator", func() {
-	testsuit.Calc = &testsuit.Calculator{}
+	testsuit.Calc = calc.NewCalculator()
+	testsuit.Session = testsuit.Calc.NewSession()
 	testsuit.Numbers = []int{}
 	testsuit.Result = 0
 	testsuit.Error = ""
@@ -22,30 +25,35 @@ var _ = gauge.Step("Enter number <number>", func(number int) {
 	testsuit.Numbers = append(testsuit.Numbers, number)
 })
 
+// Operations are dispatched by name through the shared registry. The
+// entered numbers are consumed so the next operation starts afresh.
 var _ = gauge.Step("Press <operation> button", func(operation string) {
-	if len(testsuit.Numbers) < 2 {
-		testsuit.Error = "need at least 2 numbers"
+	result, err := testsuit.Session.Apply(operation, testsuit.Numbers...)
+	testsuit.Numbers = []int{}
+	if err != nil {
+		testsuit.Error = err.Error()
 		return
 	}
+	testsuit.Result, testsuit.Error = result, ""
+})
 
-	a, b := testsuit.Numbers[0], testsuit.Numbers[1]
+// Memory keys (M+, M-, MR, MC) act on the current session value. M+ and M-
+// are ignored while an error is displayed.
+var _ = gauge.Step("Press memory <key>", func(key string) {
+	err := testsuit.Session.PressMemory(key)
+	if err != nil && !errors.Is(err, calc.ErrErrorDisplayed) {
+		panic(fmt.Sprintf("Memory key %s failed: %v", key, err))
+	}
+	result, err := testsuit.Session.Value()
+	testsuit.Result, testsuit.Error = result, ""
+	if err != nil {
+		testsuit.Error = err.Error()
+	}
+})
 
-	switch operation {
-	case "add":
-		testsuit.Result = testsuit.Calc.Add(a, b)
-	case "subtract":
-		testsuit.Result = testsuit.Calc.Subtract(a, b)
-	case "multiply":
-		testsuit.Result = testsuit.Calc.Multiply(a, b)
-	case "divide":
-		result, err := testsuit.Calc.Divide(a, b)
-		if err != nil {
-			testsuit.Error = err.Error()
-		} else {
-			testsuit.Result = result
-		}
-	default:
-		testsuit.Error = "unknown operation: " + operation
+var _ = gauge.Step("Memory should be <expected>", func(expected int) {
+	if memory := testsuit.Session.Memory(); memory != expected {
+		panic(fmt.Sprintf("Expected memory %d but got %d", expected, memory))
 	}
 })
 
@@ -62,6 +70,12 @@ var _ = gauge.Step("Should see error <message>", func(message string) {
 	}
 })
 
+var _ = gauge.Step("Should see no error", func() {
+	if testsuit.Error != "" {
+		panic(fmt.Sprintf("Expected no error but got '%s'", testsuit.Error))
+	}
+})
+
 var _ = gauge.Step("Perform calculation with data table", func(table *models.Table) {
 	for _, row := range table.Rows {
 		operation := row.Cells[0]
@@ -70,17 +84,7 @@ var _ = gauge.Step("Perform calculation with data table", func(table *models.Tab
 		expectedResult := parseInt(row.Cells[3])
 
 		testsuit.Numbers = []int{first, second}
-
-		switch operation {
-		case "add":
-			testsuit.Result = testsuit.Calc.Add(first, second)
-		case "subtract":
-			testsuit.Result = testsuit.Calc.Subtract(first, second)
-		case "multiply":
-			testsuit.Result = testsuit.Calc.Multiply(first, second)
-		case "divide":
-			testsuit.Result, _ = testsuit.Calc.Divide(first, second)
-		}
+		testsuit.Result, _ = testsuit.Calc.Apply(operation, first, second)
 
 		if testsuit.Result != expectedResult {
 			panic(fmt.Sprintf("For %s(%d, %d): expected %d but got %d",
@@ -94,25 +98,3 @@ func parseInt(s string) int {
 	_, _ = fmt.Sscanf(s, "%d", &i) // Error ignored - returns 0 on failure which is acceptable for tests
 	return i
 }
-
-// Calculator provides basic arithmetic operations.
-type Calculator struct{}
-
-func (c *Calculator) Add(a, b int) int {
-	return a + b
-}
-
-func (c *Calculator) Subtract(a, b int) int {
-	return a - b
-}
-
-func (c *Calculator) Multiply(a, b int) int {
-	return a * b
-}
-
-func (c *Calculator) Divide(a, b int) (int, error) {
-	if b == 0 {
-		return 0, fmt.Errorf("cannot divide by zero")
-	}
-	return a / b, nil
-}
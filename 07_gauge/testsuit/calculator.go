@@ -1,33 +1,12 @@
 package testsuit
 
-import "fmt"
-
-// Calculator provides arithmetic operations.
-type Calculator struct{}
+import "github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
 
 // Global test state for Gauge scenarios
 var (
-	Calc    *Calculator
+	Calc    *calc.Calculator
+	Session *calc.Session
 	Numbers []int
 	Result  int
 	Error   string
 )
-
-func (c *Calculator) Add(a, b int) int {
-	return a + b
-}
-
-func (c *Calculator) Subtract(a, b int) int {
-	return a - b
-}
-
-func (c *Calculator) Multiply(a, b int) int {
-	return a * b
-}
-
-func (c *Calculator) Divide(a, b int) (int, error) {
-	if b == 0 {
-		return 0, fmt.Errorf("cannot divide by zero")
-	}
-	return a / b, nil
-}
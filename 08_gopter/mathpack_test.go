@@ -0,0 +1,188 @@
+package gopter
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestPowerProperties demonstrates property-based testing for power.
+func TestPowerProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+
+	properties.Property("x^0 is 1", prop.ForAll(
+		func(a int) bool {
+			p, err := calc.Power(a, 0)
+			return err == nil && p == 1
+		},
+		gen.Int(),
+	))
+
+	properties.Property("x^(m+n) == x^m * x^n", prop.ForAll(
+		func(a, m, n int) bool {
+			pmn, err1 := calc.Power(a, m+n)
+			pm, err2 := calc.Power(a, m)
+			pn, err3 := calc.Power(a, n)
+			return err1 == nil && err2 == nil && err3 == nil && pmn == pm*pn
+		},
+		gen.IntRange(-7, 7), gen.IntRange(0, 10), gen.IntRange(0, 10),
+	))
+
+	properties.Property("agrees with math/big or reports overflow", prop.ForAll(
+		func(a, n int) bool {
+			want := new(big.Int).Exp(big.NewInt(int64(a)), big.NewInt(int64(n)), nil)
+			p, err := calc.Power(a, n)
+			if !want.IsInt64() {
+				return errors.Is(err, calc.ErrOverflow)
+			}
+			return err == nil && int64(p) == want.Int64()
+		},
+		gen.IntRange(-1000, 1000), gen.IntRange(0, 20),
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestGCDLCMProperties demonstrates properties relating gcd and lcm.
+func TestGCDLCMProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	small := gen.IntRange(-1_000_000, 1_000_000)
+
+	properties.Property("gcd divides both operands", prop.ForAll(
+		func(a, b int) bool {
+			g, err := calc.GCD(a, b)
+			if err != nil || g < 0 {
+				return false
+			}
+			if g == 0 {
+				return a == 0 && b == 0
+			}
+			return a%g == 0 && b%g == 0
+		},
+		gen.Int(), gen.Int(),
+	))
+
+	properties.Property("gcd is commutative", prop.ForAll(
+		func(a, b int) bool {
+			g1, _ := calc.GCD(a, b)
+			g2, _ := calc.GCD(b, a)
+			return g1 == g2
+		},
+		gen.Int(), gen.Int(),
+	))
+
+	properties.Property("gcd * lcm == |a * b|", prop.ForAll(
+		func(a, b int) bool {
+			g, _ := calc.GCD(a, b)
+			l, err := calc.LCM(a, b)
+			product := a * b
+			if product < 0 {
+				product = -product
+			}
+			return err == nil && g*l == product
+		},
+		small, small,
+	))
+
+	properties.Property("lcm is a multiple of both operands", prop.ForAll(
+		func(a, b int) bool {
+			l, err := calc.LCM(a, b)
+			if err != nil {
+				return false
+			}
+			if a == 0 || b == 0 {
+				return l == 0
+			}
+			return l > 0 && l%a == 0 && l%b == 0
+		},
+		small, small,
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestModPowProperties demonstrates checking modpow against math/big.
+func TestModPowProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+
+	properties.Property("agrees with big.Int.Exp", prop.ForAll(
+		func(base, exp, m int) bool {
+			got, err := calc.ModPow(base, exp, m)
+			if err != nil {
+				return false
+			}
+			b := new(big.Int).Mod(big.NewInt(int64(base)), big.NewInt(int64(m)))
+			want := new(big.Int).Exp(b, big.NewInt(int64(exp)), big.NewInt(int64(m)))
+			return int64(got) == want.Int64()
+		},
+		gen.Int(), gen.IntRange(0, 1<<20), gen.IntRange(1, 1<<62),
+	))
+
+	properties.Property("result is in [0, m)", prop.ForAll(
+		func(base, exp, m int) bool {
+			got, err := calc.ModPow(base, exp, m)
+			return err == nil && got >= 0 && got < m
+		},
+		gen.Int(), gen.IntRange(0, 1000), gen.IntRange(1, 1000),
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestFactorialProperties demonstrates a recursive definition as a property.
+func TestFactorialProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+
+	properties.Property("n! == n * (n-1)!", prop.ForAll(
+		func(n int) bool {
+			f, err1 := calc.Factorial(n)
+			prev, err2 := calc.Factorial(n - 1)
+			return err1 == nil && err2 == nil && f == n*prev
+		},
+		gen.IntRange(1, 20),
+	))
+
+	properties.Property("overflows past 20!", prop.ForAll(
+		func(n int) bool {
+			_, err := calc.Factorial(n)
+			return errors.Is(err, calc.ErrOverflow)
+		},
+		gen.IntRange(21, 1000),
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestISqrtProperties demonstrates bounding the integer square root.
+func TestISqrtProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+
+	properties.Property("r*r <= n < (r+1)*(r+1)", prop.ForAll(
+		func(n int) bool {
+			r, err := calc.ISqrt(n)
+			if err != nil {
+				return false
+			}
+			next := new(big.Int).Mul(big.NewInt(int64(r+1)), big.NewInt(int64(r+1)))
+			return r*r <= n && next.Cmp(big.NewInt(int64(n))) > 0
+		},
+		gen.IntRange(0, math.MaxInt),
+	))
+
+	properties.Property("isqrt(n*n) == n", prop.ForAll(
+		func(n int) bool {
+			r, err := calc.ISqrt(n * n)
+			return err == nil && r == n
+		},
+		gen.IntRange(0, 3_037_000_499),
+	))
+
+	properties.TestingRun(t)
+}
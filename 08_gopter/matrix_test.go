@@ -0,0 +1,172 @@
+package gopter
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// reshape builds a rows x cols matrix from the first rows*cols values.
+func reshape(rows, cols int, values []int) calc.Matrix {
+	m := calc.NewMatrix(rows, cols)
+	for i := range m {
+		copy(m[i], values[i*cols:(i+1)*cols])
+	}
+	return m
+}
+
+// smallValues generates enough small entries for any matrix up to 4x4.
+func smallValues() gopter.Gen {
+	return gen.SliceOfN(16, gen.IntRange(-9, 9))
+}
+
+// TestVectorProperties demonstrates element-wise laws on integer slices.
+func TestVectorProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	pairs := gen.SliceOf(gen.IntRange(-1000, 1000)).Map(func(a []int) [2][]int {
+		b := Reverse(a) // a second vector of the same length
+		return [2][]int{a, b}
+	})
+
+	properties.Property("vector addition is commutative", prop.ForAll(
+		func(p [2][]int) bool {
+			ab, err1 := calc.AddVectors(p[0], p[1])
+			ba, err2 := calc.AddVectors(p[1], p[0])
+			return err1 == nil && err2 == nil && reflect.DeepEqual(ab, ba)
+		},
+		pairs,
+	))
+
+	properties.Property("a - b + b == a", prop.ForAll(
+		func(p [2][]int) bool {
+			diff, _ := calc.SubtractVectors(p[0], p[1])
+			back, err := calc.AddVectors(diff, p[1])
+			return err == nil && ((len(back) == 0 && len(p[0]) == 0) || reflect.DeepEqual(back, p[0]))
+		},
+		pairs,
+	))
+
+	properties.Property("dot product is the sum of the Hadamard product", prop.ForAll(
+		func(p [2][]int) bool {
+			d, err := calc.Dot(p[0], p[1])
+			product, _ := calc.MultiplyVectors(p[0], p[1])
+			return err == nil && d == sumSlice(product)
+		},
+		pairs,
+	))
+
+	properties.Property("reversing both vectors keeps the dot product", prop.ForAll(
+		func(p [2][]int) bool {
+			d1, _ := calc.Dot(p[0], p[1])
+			d2, _ := calc.Dot(Reverse(p[0]), Reverse(p[1]))
+			return d1 == d2
+		},
+		pairs,
+	))
+
+	properties.Property("length mismatch is a DimensionError", prop.ForAll(
+		func(a []int, extra int) bool {
+			_, err := calc.AddVectors(a, append(append([]int{}, a...), extra))
+			var dimErr *calc.DimensionError
+			return errors.As(err, &dimErr) && dimErr.Want == len(a) && dimErr.Got == len(a)+1
+		},
+		gen.SliceOf(gen.Int()), gen.Int(),
+	))
+
+	properties.TestingRun(t)
+}
+
+func sumSlice(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// TestMatrixProperties demonstrates Reverse-style involution and algebraic
+// laws for matrices.
+func TestMatrixProperties(t *testing.T) {
+	properties := gopter.NewProperties(gopter.DefaultTestParameters())
+	dim := gen.IntRange(1, 4)
+
+	properties.Property("transposing twice gives the original", prop.ForAll(
+		func(r, c int, values []int) bool {
+			m := reshape(r, c, values)
+			t1, _ := calc.Transpose(m)
+			t2, err := calc.Transpose(t1)
+			return err == nil && reflect.DeepEqual(t2, m)
+		},
+		dim, dim, smallValues(),
+	))
+
+	properties.Property("(AB)^T == B^T A^T", prop.ForAll(
+		func(r, k, c int, values []int) bool {
+			a, b := reshape(r, k, values), reshape(k, c, Reverse(values))
+			ab, err := calc.MatMul(a, b)
+			if err != nil {
+				return false
+			}
+			abT, _ := calc.Transpose(ab)
+			aT, _ := calc.Transpose(a)
+			bT, _ := calc.Transpose(b)
+			bTaT, err := calc.MatMul(bT, aT)
+			return err == nil && reflect.DeepEqual(abT, bTaT)
+		},
+		dim, dim, dim, smallValues(),
+	))
+
+	properties.Property("det(A^T) == det(A)", prop.ForAll(
+		func(n int, values []int) bool {
+			m := reshape(n, n, values)
+			mT, _ := calc.Transpose(m)
+			d1, err1 := calc.Determinant(m)
+			d2, err2 := calc.Determinant(mT)
+			return err1 == nil && err2 == nil && d1 == d2
+		},
+		dim, smallValues(),
+	))
+
+	properties.Property("det(AB) == det(A) * det(B)", prop.ForAll(
+		func(n int, values []int) bool {
+			a, b := reshape(n, n, values), reshape(n, n, Reverse(values))
+			ab, _ := calc.MatMul(a, b)
+			dab, err := calc.Determinant(ab)
+			da, _ := calc.Determinant(a)
+			db, _ := calc.Determinant(b)
+			return err == nil && dab == da*db
+		},
+		dim, smallValues(),
+	))
+
+	properties.Property("reversing the rows of a 2x2 or 3x3 flips the determinant's sign", prop.ForAll(
+		func(n int, values []int) bool {
+			m := reshape(n, n, values)
+			flipped := make(calc.Matrix, n)
+			for i := range m {
+				flipped[n-1-i] = m[i]
+			}
+			d, _ := calc.Determinant(m)
+			df, _ := calc.Determinant(flipped)
+			return df == -d
+		},
+		gen.IntRange(2, 3), smallValues(),
+	))
+
+	properties.Property("mismatched inner dimensions are a DimensionError", prop.ForAll(
+		func(r, k, c int, values []int) bool {
+			_, err := calc.MatMul(reshape(r, k, values), reshape(k+1, c, values))
+			var dimErr *calc.DimensionError
+			return errors.As(err, &dimErr) && dimErr.Want == k && dimErr.Got == k+1
+		},
+		dim, gen.IntRange(1, 3), dim, smallValues(),
+	))
+
+	properties.TestingRun(t)
+}
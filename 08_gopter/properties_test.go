@@ -6,16 +6,18 @@ import (
 	"github.com/leanovate/gopter"
 	"github.com/leanovate/gopter/gen"
 	"github.com/leanovate/gopter/prop"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/numeric"
 )
 
 // Sum returns the sum of two integers.
 func Sum(a, b int) int {
-	return a + b
+	return numeric.Sum(a, b)
 }
 
 // Multiply returns the product of two integers.
 func Multiply(a, b int) int {
-	return a * b
+	return numeric.Multiply(a, b)
 }
 
 // Abs returns the absolute value of an integer.
@@ -0,0 +1,145 @@
+package rapid
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// TestFactorizeProduct demonstrates the "product of factors equals input"
+// property, along with every factor being prime and the list being sorted.
+func TestFactorizeProduct(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.IntRange(1, math.MaxInt).Draw(t, "n")
+
+		factors, err := calc.Factorize(n)
+		if err != nil {
+			t.Fatalf("Factorize(%d) error = %v", n, err)
+		}
+
+		product := 1
+		for i, f := range factors {
+			if !calc.IsPrime(f) {
+				t.Fatalf("Factorize(%d) contains non-prime %d", n, f)
+			}
+			if i > 0 && f < factors[i-1] {
+				t.Fatalf("Factorize(%d) = %v is not sorted", n, factors)
+			}
+			product *= f
+		}
+		if product != n {
+			t.Fatalf("product of %v = %d; want %d", factors, product, n)
+		}
+	})
+}
+
+// TestFactorizeComposed builds n from known primes and expects them back.
+func TestFactorizeComposed(t *testing.T) {
+	primes := []int{2, 3, 5, 7, 11, 13, 997, 1009, 65537, 999983, 1000003}
+
+	rapid.Check(t, func(t *rapid.T) {
+		n, want := 1, []int{}
+		for _, p := range rapid.SliceOfN(rapid.SampledFrom(primes), 1, 8).Draw(t, "primes") {
+			next, err := calc.MultiplyChecked(n, p)
+			if err != nil {
+				break
+			}
+			n = next
+			want = append(want, p)
+		}
+
+		got, err := calc.Factorize(n)
+		if err != nil {
+			t.Fatalf("Factorize(%d) error = %v", n, err)
+		}
+		counts := map[int]int{}
+		for _, p := range want {
+			counts[p]++
+		}
+		for _, p := range got {
+			counts[p]--
+		}
+		for p, c := range counts {
+			if c != 0 {
+				t.Fatalf("Factorize(%d) = %v; want the multiset %v (prime %d off by %d)", n, got, want, p, c)
+			}
+		}
+	})
+}
+
+// TestIsPrimeMatchesBig compares Miller-Rabin with math/big, which is exact
+// below 2^64.
+func TestIsPrimeMatchesBig(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.OneOf(
+			rapid.IntRange(-10, 10_000),
+			rapid.IntRange(1, math.MaxInt),
+		).Draw(t, "n")
+
+		want := n > 1 && big.NewInt(int64(n)).ProbablyPrime(0)
+		if got := calc.IsPrime(n); got != want {
+			t.Fatalf("IsPrime(%d) = %v; want %v", n, got, want)
+		}
+	})
+}
+
+// TestGCDLCMLaws demonstrates generator-driven checks of gcd and lcm.
+func TestGCDLCMLaws(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		a := rapid.IntRange(-1_000_000, 1_000_000).Draw(t, "a")
+		b := rapid.IntRange(-1_000_000, 1_000_000).Draw(t, "b")
+		k := rapid.IntRange(1, 1000).Draw(t, "k")
+
+		g, _ := calc.GCD(a, b)
+		gk, _ := calc.GCD(a*k, b*k)
+		if gk != g*k {
+			t.Fatalf("gcd(%d*%d, %d*%d) = %d; want %d", a, k, b, k, gk, g*k)
+		}
+
+		l, _ := calc.LCM(a, b)
+		if abs := max(a*b, -a*b); g*l != abs {
+			t.Fatalf("gcd(%d, %d) * lcm = %d; want |a*b| = %d", a, b, g*l, abs)
+		}
+	})
+}
+
+// TestBitOperationLaws demonstrates properties of shifts, rotates and
+// popcount.
+func TestBitOperationLaws(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		n := rapid.Int().Draw(t, "n")
+		k := rapid.IntRange(-200, 200).Draw(t, "k")
+
+		if got := calc.RotateRight(calc.RotateLeft(n, k), k); got != n {
+			t.Fatalf("rotating %d by %d and back = %d", n, k, got)
+		}
+		if calc.PopCount(calc.RotateLeft(n, k)) != calc.PopCount(n) {
+			t.Fatalf("rotating %d by %d changed its popcount", n, k)
+		}
+		if calc.PopCount(n)+calc.PopCount(^n) != bits.UintSize {
+			t.Fatalf("popcount(%d) + popcount(^%d) != %d", n, n, bits.UintSize)
+		}
+
+		s := rapid.IntRange(0, bits.UintSize+8).Draw(t, "s")
+		shifted, err := calc.ShiftLeft(n, s)
+		want := new(big.Int).Lsh(big.NewInt(int64(n)), uint(s))
+		if !want.IsInt64() {
+			if !errors.Is(err, calc.ErrOverflow) {
+				t.Fatalf("ShiftLeft(%d, %d) = %d, %v; want overflow", n, s, shifted, err)
+			}
+			return
+		}
+		if err != nil || int64(shifted) != want.Int64() {
+			t.Fatalf("ShiftLeft(%d, %d) = %d, %v; want %s", n, s, shifted, err, want)
+		}
+		if back, _ := calc.ShiftRight(shifted, s); back != n {
+			t.Fatalf("ShiftRight(ShiftLeft(%d, %d)) = %d", n, s, back)
+		}
+	})
+}
@@ -0,0 +1,140 @@
+package rapid
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"pgregory.net/rapid"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// rpnMachine drives a calc.RPN and a plain slice model with the same random
+// commands and checks that they always agree.
+type rpnMachine struct {
+	rpn   *calc.RPN
+	model []int
+}
+
+// underflow checks that an operation needing n values fails when the model
+// has fewer, and reports whether the command can go ahead.
+func (m *rpnMachine) underflow(t *rapid.T, err error, n int) bool {
+	if len(m.model) >= n {
+		return false
+	}
+	var underflow *calc.StackUnderflowError
+	if !errors.As(err, &underflow) || underflow.Need != n || underflow.Have != len(m.model) {
+		t.Fatalf("with %d values, error = %v; want stack underflow needing %d", len(m.model), err, n)
+	}
+	return true
+}
+
+func (m *rpnMachine) push(t *rapid.T) {
+	v := rapid.Int().Draw(t, "value")
+	m.rpn.Push(v)
+	m.model = append(m.model, v)
+}
+
+func (m *rpnMachine) pop(t *rapid.T) {
+	v, err := m.rpn.Pop()
+	if m.underflow(t, err, 1) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("Pop() error = %v", err)
+	}
+	want := m.model[len(m.model)-1]
+	m.model = m.model[:len(m.model)-1]
+	if v != want {
+		t.Fatalf("Pop() = %d; want %d", v, want)
+	}
+}
+
+func (m *rpnMachine) dup(t *rapid.T) {
+	err := m.rpn.Dup()
+	if m.underflow(t, err, 1) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("Dup() error = %v", err)
+	}
+	m.model = append(m.model, m.model[len(m.model)-1])
+}
+
+func (m *rpnMachine) swap(t *rapid.T) {
+	err := m.rpn.Swap()
+	if m.underflow(t, err, 2) {
+		return
+	}
+	if err != nil {
+		t.Fatalf("Swap() error = %v", err)
+	}
+	n := len(m.model)
+	m.model[n-1], m.model[n-2] = m.model[n-2], m.model[n-1]
+}
+
+// binary returns a command applying op, with the model computing the
+// expected result in arbitrary precision so overflow is detected
+// independently of the calc package.
+func (m *rpnMachine) binary(op string, fn func(z, a, b *big.Int) *big.Int) func(*rapid.T) {
+	return func(t *rapid.T) {
+		result, err := m.rpn.Apply(op)
+		if m.underflow(t, err, 2) {
+			return
+		}
+
+		n := len(m.model)
+		a, b := big.NewInt(int64(m.model[n-2])), big.NewInt(int64(m.model[n-1]))
+		var want error
+		var expected *big.Int
+		if op == "divide" && b.Sign() == 0 {
+			want = calc.ErrDivisionByZero
+		} else if expected = fn(new(big.Int), a, b); !expected.IsInt64() {
+			want = calc.ErrOverflow
+		}
+
+		if want != nil {
+			if !errors.Is(err, want) {
+				t.Fatalf("%s(%d, %d) error = %v; want %v", op, a, b, err, want)
+			}
+			return // the stack must be unchanged, which check verifies
+		}
+		if err != nil {
+			t.Fatalf("%s(%d, %d) error = %v", op, a, b, err)
+		}
+		if int64(result) != expected.Int64() {
+			t.Fatalf("%s(%d, %d) = %d; want %s", op, a, b, result, expected)
+		}
+		m.model = append(m.model[:n-2], result)
+	}
+}
+
+func (m *rpnMachine) check(t *rapid.T) {
+	if got := m.rpn.Stack(); len(got) != len(m.model) || (len(got) > 0 && !reflect.DeepEqual(got, m.model)) {
+		t.Fatalf("stack = %v; model = %v", got, m.model)
+	}
+	if m.rpn.Len() != len(m.model) {
+		t.Fatalf("Len() = %d; model has %d values", m.rpn.Len(), len(m.model))
+	}
+}
+
+// TestRPNStateMachine demonstrates stateful testing of the RPN calculator
+// against a slice model using rapid's t.Repeat.
+func TestRPNStateMachine(t *testing.T) {
+	rapid.Check(t, func(t *rapid.T) {
+		m := &rpnMachine{rpn: calc.NewCalculator().NewRPN()}
+		t.Repeat(map[string]func(*rapid.T){
+			"push":     m.push,
+			"pop":      m.pop,
+			"dup":      m.dup,
+			"swap":     m.swap,
+			"add":      m.binary("add", (*big.Int).Add),
+			"subtract": m.binary("subtract", (*big.Int).Sub),
+			"multiply": m.binary("multiply", (*big.Int).Mul),
+			"divide":   m.binary("divide", (*big.Int).Quo),
+			"":         m.check,
+		})
+	})
+}
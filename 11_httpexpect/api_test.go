@@ -2,18 +2,37 @@ package httpexpect
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/gavv/httpexpect/v2"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
 )
 
-// User represents a user in our API.
-type User struct {
-	ID    int    `json:"id"`
-	Name  string `json:"name"`
-	Email string `json:"email"`
+// User is the shared domain user; its JSON tags define the API shape.
+type User = domain.User
+
+// fieldErrorJSON is one entry in the "fields" list of a validation error.
+type fieldErrorJSON struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// writeValidationError reports every invalid field as a 400 response.
+func writeValidationError(w http.ResponseWriter, verr *domain.ValidationError) {
+	fields := make([]fieldErrorJSON, len(verr.Fields))
+	for i, f := range verr.Fields {
+		fields[i] = fieldErrorJSON{Field: f.Field, Message: f.Err.Error()}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"error":  verr.Error(),
+		"fields": fields,
+	})
 }
 
 // createAPIHandler creates a simple REST API handler for testing.
@@ -71,12 +90,19 @@ func createAPIHandler() http.Handler {
 			return
 		}
 
-		var user User
-		if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
+		var input User
+		if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
 			http.Error(w, "Invalid JSON", http.StatusBadRequest)
 			return
 		}
 
+		user, err := domain.NewUser(input.Name, input.Email)
+		var verr *domain.ValidationError
+		if errors.As(err, &verr) {
+			writeValidationError(w, verr)
+			return
+		}
+
 		user.ID = 3 // Simulate ID assignment
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusCreated)
@@ -193,6 +219,32 @@ func TestCreateUser(t *testing.T) {
 	obj.ContainsKey("email")
 }
 
+// TestCreateUser_Validation demonstrates asserting on a structured
+// validation error that lists every invalid field.
+func TestCreateUser_Validation(t *testing.T) {
+	handler := createAPIHandler()
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	e := httpexpect.Default(t, server.URL)
+
+	obj := e.POST("/users/create").
+		WithJSON(map[string]string{"name": "  ", "email": "not-an-email"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object()
+
+	obj.NotContainsKey("id")
+	fields := obj.Value("fields").Array()
+	fields.Length().IsEqual(2)
+	fields.Value(0).Object().
+		HasValue("field", "name").
+		HasValue("message", "is required")
+	fields.Value(1).Object().
+		HasValue("field", "email").
+		HasValue("message", "is not a valid email address")
+}
+
 // TestWithHandler demonstrates testing handlers directly (without server).
 func TestWithHandler(t *testing.T) {
 	handler := createAPIHandler()
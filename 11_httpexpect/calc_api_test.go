@@ -0,0 +1,211 @@
+package httpexpect
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gavv/httpexpect/v2"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/httpapi"
+)
+
+// newCalcServer starts the calculator API on a test server.
+func newCalcServer(t *testing.T) *httpexpect.Expect {
+	t.Helper()
+	server := httptest.NewServer(httpapi.NewHandler(calc.NewCalculator()))
+	t.Cleanup(server.Close)
+	return httpexpect.Default(t, server.URL)
+}
+
+// TestCalcAPI_Operations mirrors the scenarios in 06_godog/features at the
+// API boundary: the same operands must give the same results.
+func TestCalcAPI_Operations(t *testing.T) {
+	e := newCalcServer(t)
+
+	tests := []struct {
+		name     string
+		op       string
+		operands []int
+		result   int
+	}{
+		// calculator.feature
+		{"Add two positive numbers", "add", []int{2, 3}, 5},
+		{"Add negative numbers", "add", []int{-5, -3}, -8},
+		{"Multiply numbers", "multiply", []int{4, 5}, 20},
+		{"Divide numbers", "divide", []int{10, 2}, 5},
+		{"Outline add", "add", []int{1, 1}, 2},
+		{"Outline subtract", "subtract", []int{10, 5}, 5},
+		{"Outline multiply", "multiply", []int{3, 7}, 21},
+		{"Outline divide", "divide", []int{20, 4}, 5},
+		{"Outline add zero", "add", []int{0, 5}, 5},
+		{"Outline divide by ten", "divide", []int{100, 10}, 10},
+		// math_pack.feature
+		{"Raise a number to a power", "power", []int{2, 10}, 1024},
+		{"Factorial takes a single number", "factorial", []int{5}, 120},
+		{"Modular exponentiation takes three numbers", "modpow", []int{4, 13, 497}, 445},
+		{"Outline gcd", "gcd", []int{12, 18}, 6},
+		{"Outline lcm", "lcm", []int{4, 6}, 12},
+		{"Outline negative power", "power", []int{-2, 3}, -8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e.POST("/calc").
+				WithJSON(httpapi.Request{Op: tt.op, Operands: tt.operands}).
+				Expect().
+				Status(http.StatusOK).
+				HasContentType("application/json").
+				JSON().Object().
+				IsEqual(map[string]interface{}{"result": tt.result})
+		})
+	}
+}
+
+// TestCalcAPI_CalculationErrors demonstrates asserting on JSON error bodies.
+func TestCalcAPI_CalculationErrors(t *testing.T) {
+	e := newCalcServer(t)
+
+	t.Run("Division by zero returns error", func(t *testing.T) {
+		obj := e.POST("/calc").
+			WithJSON(httpapi.Request{Op: "divide", Operands: []int{10, 0}}).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object()
+
+		obj.NotContainsKey("result")
+		obj.Value("error").Object().
+			HasValue("code", httpapi.CodeDivisionByZero).
+			HasValue("message", "cannot divide by zero")
+	})
+
+	t.Run("Negative exponents are rejected", func(t *testing.T) {
+		e.POST("/calc").
+			WithJSON(httpapi.Request{Op: "power", Operands: []int{2, -1}}).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object().
+			Value("error").Object().
+			HasValue("code", httpapi.CodeDomain).
+			HasValue("message", "operand out of domain")
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		e.POST("/calc").
+			WithJSON(httpapi.Request{Op: "add", Operands: []int{9223372036854775807, 1}}).
+			Expect().
+			Status(http.StatusUnprocessableEntity).
+			JSON().Object().
+			Value("error").Object().
+			HasValue("code", httpapi.CodeOverflow)
+	})
+}
+
+// TestCalcAPI_Expressions demonstrates evaluating expressions over HTTP.
+func TestCalcAPI_Expressions(t *testing.T) {
+	e := newCalcServer(t)
+
+	e.POST("/calc").
+		WithJSON(map[string]string{"expression": "(2 + 3) * -4 / 2"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		HasValue("result", -10)
+
+	e.POST("/calc").
+		WithJSON(map[string]string{"expression": "gcd(12, 18) + power(2, 3)"}).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().
+		HasValue("result", 14)
+
+	e.POST("/calc").
+		WithJSON(map[string]string{"expression": "1 / (2 - 2)"}).
+		Expect().
+		Status(http.StatusUnprocessableEntity).
+		JSON().Object().
+		Value("error").Object().
+		HasValue("code", httpapi.CodeDivisionByZero).
+		HasValue("message", "cannot divide by zero")
+
+	e.POST("/calc").
+		WithJSON(map[string]string{"expression": "2 +"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().
+		Value("error").Object().
+		HasValue("code", httpapi.CodeSyntaxError).
+		HasValue("position", 4)
+
+	e.POST("/calc").
+		WithJSON(map[string]string{"expression": "x + 1"}).
+		Expect().
+		Status(http.StatusBadRequest).
+		JSON().Object().
+		Value("error").Object().
+		HasValue("code", httpapi.CodeUndefinedVariable).
+		HasValue("position", 1)
+}
+
+// TestCalcAPI_BadRequests demonstrates table-driven checks of 4xx responses.
+func TestCalcAPI_BadRequests(t *testing.T) {
+	e := newCalcServer(t)
+
+	tests := []struct {
+		name    string
+		body    interface{}
+		code    string
+		message string
+	}{
+		{"unknown operation", map[string]interface{}{"op": "sqrt", "operands": []int{4}}, httpapi.CodeUnknownOperation, "unknown operation: sqrt"},
+		{"wrong arity", map[string]interface{}{"op": "add", "operands": []int{1}}, httpapi.CodeArity, "add takes 2 numbers, got 1"},
+		{"unknown function in expression", map[string]string{"expression": "sqrt(4)"}, httpapi.CodeUnknownOperation, "position 1: unknown operation: sqrt"},
+		{"empty request", map[string]string{}, httpapi.CodeInvalidRequest, "missing op or expression"},
+		{"op and expression", map[string]string{"op": "add", "expression": "1"}, httpapi.CodeInvalidRequest, "set either op or expression, not both"},
+		{"operands with expression", map[string]interface{}{"expression": "1", "operands": []int{1}}, httpapi.CodeInvalidRequest, "operands cannot be used with expression"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e.POST("/calc").
+				WithJSON(tt.body).
+				Expect().
+				Status(http.StatusBadRequest).
+				JSON().Object().
+				Value("error").Object().
+				HasValue("code", tt.code).
+				HasValue("message", tt.message)
+		})
+	}
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		e.POST("/calc").
+			WithText("{not json").
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().
+			Value("error").Object().
+			HasValue("code", httpapi.CodeInvalidRequest)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		e.POST("/calc").
+			WithJSON(map[string]interface{}{"operation": "add", "operands": []int{1, 2}}).
+			Expect().
+			Status(http.StatusBadRequest).
+			JSON().Object().
+			Value("error").Object().
+			HasValue("code", httpapi.CodeInvalidRequest)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		resp := e.GET("/calc").
+			Expect().
+			Status(http.StatusMethodNotAllowed)
+		resp.Header("Allow").IsEqual(http.MethodPost)
+		resp.JSON().Object().
+			Value("error").Object().
+			HasValue("code", httpapi.CodeMethodNotAllowed)
+	})
+}
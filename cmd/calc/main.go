@@ -0,0 +1,273 @@
+// Command calc exposes the calc package on the command line, so shell
+// scripts can use exactly the arithmetic the test suites verify.
+//
+// Usage:
+//
+//	calc [--json] [--mode=truncated|floored|euclidean] <command> [args]
+//	calc [--history=FILE] repl
+//	calc serve [ADDR]
+//
+// Commands:
+//
+//	add A B        A + B
+//	subtract A B   A - B
+//	multiply A B   A * B
+//	divide A B     A / B, rounded according to --mode
+//	mod A B        remainder of A / B according to --mode
+//	power A B      A raised to B
+//	gcd A B        greatest common divisor
+//	lcm A B        least common multiple
+//	modpow A B M   A raised to B, modulo M
+//	factorial N    N!
+//	isqrt N        integer square root
+//	eval EXPR      evaluate an expression such as "2*(3+4)" or "gcd(12, 18)"
+//	repl           start an interactive session with variables and history
+//	serve [ADDR]   serve POST /calc over HTTP (default localhost:8080)
+//
+// Any other operation registered in calc.DefaultRegistry is available as a
+// command too, taking as many numbers as its arity.
+//
+// Flags may also follow the command, as in "calc add 2 3 --json". There,
+// only arguments naming a calc flag are flags, so negative numbers and
+// expressions such as "-5+3" are still operands; "--" ends the flags.
+//
+// Exit status is 0 on success, 1 if the calculation fails (for example on
+// division by zero or overflow) and 2 for usage or parse errors.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// Exit codes.
+const (
+	exitOK    = 0
+	exitCalc  = 1
+	exitUsage = 2
+)
+
+const usage = `usage: calc [--json] [--mode=truncated|floored|euclidean] <command> [args] [flags]
+       calc [--history=FILE] repl
+       calc serve [ADDR]
+
+commands:
+  add A B        A + B
+  subtract A B   A - B
+  multiply A B   A * B
+  divide A B     A / B
+  mod A B        remainder of A / B
+  power A B      A raised to B
+  gcd A B        greatest common divisor
+  lcm A B        least common multiple
+  modpow A B M   A raised to B, modulo M
+  factorial N    N!
+  isqrt N        integer square root
+  eval EXPR      evaluate an expression such as "2*(3+4)" or "gcd(12, 18)"
+  repl           start an interactive session with variables and history
+  serve [ADDR]   serve POST /calc over HTTP (default localhost:8080)
+
+any operation registered in the calc package is also a command.
+`
+
+// usageError marks errors caused by bad input rather than bad arithmetic.
+type usageError struct {
+	msg string
+}
+
+func (e *usageError) Error() string {
+	return e.msg
+}
+
+// result is the --json output shape.
+type result struct {
+	Result   *int   `json:"result,omitempty"`
+	Error    string `json:"error,omitempty"`
+	Position int    `json:"position,omitempty"`
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run executes one command and returns the process exit code.
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("calc", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() { fmt.Fprint(stderr, usage) }
+	jsonOutput := flags.Bool("json", false, "print results as JSON")
+	modeName := flags.String("mode", "truncated", "division rounding: truncated, floored or euclidean")
+	historyPath := flags.String("history", defaultHistoryPath(), "REPL history file; empty disables history")
+
+	args, err := parseArgs(flags, args)
+	if err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+
+	if len(args) > 0 {
+		switch args[0] {
+		case "repl":
+			return runREPL(*historyPath, stdin, stdout, stderr)
+		case "serve":
+			return runServe(args[1:], stderr)
+		}
+	}
+
+	value, err := execute(args, *modeName)
+	if *jsonOutput {
+		return writeJSON(stdout, value, err)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "calc: %v\n", err)
+		var ue *usageError
+		if errors.As(err, &ue) {
+			fmt.Fprint(stderr, usage)
+		}
+		return exitCode(err)
+	}
+	fmt.Fprintln(stdout, value)
+	return exitOK
+}
+
+// parseArgs parses flags before and after the command and returns the
+// remaining arguments, starting with the command.
+func parseArgs(flags *flag.FlagSet, args []string) ([]string, error) {
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+	var positional []string
+	for rest := flags.Args(); len(rest) > 0; {
+		switch {
+		case rest[0] == "--":
+			return append(positional, rest[1:]...), nil
+		case isFlag(flags, rest[0]):
+			if err := flags.Parse(rest); err != nil {
+				return nil, err
+			}
+			rest = flags.Args()
+		default:
+			positional = append(positional, rest[0])
+			rest = rest[1:]
+		}
+	}
+	return positional, nil
+}
+
+// isFlag reports whether arg is -name or --name, optionally followed by
+// =value, for a flag defined in flags.
+func isFlag(flags *flag.FlagSet, arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if dashes := len(arg) - len(name); dashes != 1 && dashes != 2 {
+		return false
+	}
+	name, _, _ = strings.Cut(name, "=")
+	return flags.Lookup(name) != nil
+}
+
+// execute runs the command named by args[0].
+func execute(args []string, modeName string) (int, error) {
+	if len(args) == 0 {
+		return 0, &usageError{"missing command"}
+	}
+	mode, err := parseMode(modeName)
+	if err != nil {
+		return 0, err
+	}
+
+	command, operands := args[0], args[1:]
+	if command == "eval" {
+		if len(operands) != 1 {
+			return 0, &usageError{"eval takes exactly one expression"}
+		}
+		return calc.Eval(operands[0])
+	}
+
+	numbers := make([]int, len(operands))
+	for i, operand := range operands {
+		if numbers[i], err = parseOperand(operand); err != nil {
+			return 0, err
+		}
+	}
+
+	if override, ok := modeOverrides(mode)[command]; ok && len(numbers) == 2 {
+		return override(numbers[0], numbers[1])
+	}
+	return calc.DefaultRegistry.Apply(command, numbers...)
+}
+
+// modeOverrides replaces the registry's truncated divide and mod when
+// --mode selects another rounding.
+func modeOverrides(mode calc.DivisionMode) map[string]func(a, b int) (int, error) {
+	return map[string]func(a, b int) (int, error){
+		"divide": func(a, b int) (int, error) {
+			q, _, err := calc.DivMod(a, b, mode)
+			return q, err
+		},
+		"mod": func(a, b int) (int, error) {
+			return calc.Mod(a, b, mode)
+		},
+	}
+}
+
+func parseMode(name string) (calc.DivisionMode, error) {
+	for _, mode := range []calc.DivisionMode{calc.Truncated, calc.Floored, calc.Euclidean} {
+		if mode.String() == name {
+			return mode, nil
+		}
+	}
+	return 0, &usageError{fmt.Sprintf("unknown division mode: %s", name)}
+}
+
+func parseOperand(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, &usageError{fmt.Sprintf("invalid number: %s", s)}
+	}
+	return n, nil
+}
+
+// exitCode maps an error to the documented exit status.
+func exitCode(err error) int {
+	var ue *usageError
+	var se *calc.SyntaxError
+	var ude *calc.UndefinedError
+	var uoe *calc.UnknownOperationError
+	var ae *calc.ArityError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &ue), errors.As(err, &se), errors.As(err, &ude), errors.As(err, &uoe), errors.As(err, &ae):
+		return exitUsage
+	default:
+		return exitCalc
+	}
+}
+
+func writeJSON(w io.Writer, value int, err error) int {
+	var out result
+	if err != nil {
+		out.Error = err.Error()
+		var se *calc.SyntaxError
+		var ce *calc.CallError
+		if errors.As(err, &se) {
+			out.Position = se.Pos
+		} else if errors.As(err, &ce) {
+			out.Position = ce.Pos
+		}
+	} else {
+		out.Result = &value
+	}
+	_ = json.NewEncoder(w).Encode(out) // Error ignored - nothing useful to do if stdout is gone
+	return exitCode(err)
+}
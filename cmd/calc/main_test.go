@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestRun demonstrates table-driven tests of a command-line entry point.
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		code     int
+		stdout   string
+		stderrIn string // substring expected on stderr
+	}{
+		{"add", []string{"add", "2", "3"}, exitOK, "5\n", ""},
+		{"subtract negative operand", []string{"subtract", "-2", "3"}, exitOK, "-5\n", ""},
+		{"multiply", []string{"multiply", "4", "5"}, exitOK, "20\n", ""},
+		{"divide", []string{"divide", "20", "4"}, exitOK, "5\n", ""},
+		{"divide floored", []string{"--mode=floored", "divide", "-7", "2"}, exitOK, "-4\n", ""},
+		{"mod euclidean", []string{"--mode", "euclidean", "mod", "-7", "2"}, exitOK, "1\n", ""},
+		{"eval", []string{"eval", "2*(3+4)"}, exitOK, "14\n", ""},
+		{"divide by zero", []string{"divide", "10", "0"}, exitCalc, "", "cannot divide by zero"},
+		{"eval divide by zero", []string{"eval", "1/(2-2)"}, exitCalc, "", "cannot divide by zero"},
+		{"overflow", []string{"add", "9223372036854775807", "1"}, exitCalc, "", "integer overflow"},
+		{"eval syntax error", []string{"eval", "2 +"}, exitUsage, "", "position 4"},
+		{"invalid number", []string{"add", "two", "3"}, exitUsage, "", "invalid number: two"},
+		{"wrong arity", []string{"add", "2"}, exitUsage, "", "add takes 2 numbers, got 1"},
+		{"unknown command", []string{"sqrt", "2"}, exitUsage, "", "unknown operation: sqrt"},
+		{"math pack binary", []string{"power", "2", "10"}, exitOK, "1024\n", ""},
+		{"math pack unary", []string{"factorial", "5"}, exitOK, "120\n", ""},
+		{"math pack ternary", []string{"modpow", "4", "13", "497"}, exitOK, "445\n", ""},
+		{"math pack domain error", []string{"isqrt", "-1"}, exitCalc, "", "operand out of domain"},
+		{"eval call", []string{"eval", "gcd(12, 18) * 2"}, exitOK, "12\n", ""},
+		{"eval unknown call", []string{"eval", "1 + sqrt(4)"}, exitUsage, "", "position 5: unknown operation: sqrt"},
+		{"unknown mode", []string{"--mode=round", "divide", "1", "2"}, exitUsage, "", "unknown division mode"},
+		{"missing command", nil, exitUsage, "", "missing command"},
+		{"serve extra arguments", []string{"serve", ":1", ":2"}, exitUsage, "", "serve takes at most one address"},
+		{"serve bad address", []string{"serve", "no-such-host.invalid:http:x"}, exitCalc, "", "calc: listen"},
+		{"unknown flag", []string{"--verbose", "add", "1", "2"}, exitUsage, "", "flag provided but not defined"},
+		{"flag after command", []string{"divide", "-7", "2", "--mode=floored"}, exitOK, "-4\n", ""},
+		{"flag value after command", []string{"mod", "-7", "2", "--mode", "euclidean"}, exitOK, "1\n", ""},
+		{"negative expression is not a flag", []string{"eval", "-5+3"}, exitOK, "-2\n", ""},
+		{"operands after double dash", []string{"eval", "--", "--5"}, exitOK, "5\n", ""},
+		{"bad flag value after command", []string{"divide", "1", "2", "--mode"}, exitUsage, "", "flag needs an argument"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, nil, &stdout, &stderr)
+
+			if code != tt.code {
+				t.Errorf("run(%q) exit code = %d; want %d (stderr: %s)", tt.args, code, tt.code, stderr.String())
+			}
+			if stdout.String() != tt.stdout {
+				t.Errorf("run(%q) stdout = %q; want %q", tt.args, stdout.String(), tt.stdout)
+			}
+			if !strings.Contains(stderr.String(), tt.stderrIn) {
+				t.Errorf("run(%q) stderr = %q; want it to contain %q", tt.args, stderr.String(), tt.stderrIn)
+			}
+		})
+	}
+}
+
+// TestRun_JSON checks the machine-readable output used by pipelines.
+func TestRun_JSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		args   []string
+		code   int
+		stdout string
+	}{
+		{"result", []string{"--json", "add", "2", "3"}, exitOK, `{"result":5}`},
+		{"flag after command", []string{"add", "2", "3", "--json"}, exitOK, `{"result":5}`},
+		{"zero result", []string{"--json", "subtract", "3", "3"}, exitOK, `{"result":0}`},
+		{"calculation error", []string{"--json", "divide", "1", "0"}, exitCalc, `{"error":"cannot divide by zero"}`},
+		{"syntax error", []string{"--json", "eval", "(1"}, exitUsage, `{"error":"position 1: unclosed parenthesis","position":1}`},
+		{"usage error", []string{"--json", "add"}, exitUsage, `{"error":"add takes 2 numbers, got 0"}`},
+		{"call error", []string{"--json", "eval", "gcd(1)"}, exitUsage, `{"error":"position 1: gcd takes 2 numbers, got 1","position":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			code := run(tt.args, nil, &stdout, &stderr)
+
+			if code != tt.code {
+				t.Errorf("exit code = %d; want %d", code, tt.code)
+			}
+			if got := strings.TrimSpace(stdout.String()); got != tt.stdout {
+				t.Errorf("stdout = %s; want %s", got, tt.stdout)
+			}
+			if stderr.Len() != 0 {
+				t.Errorf("stderr = %q; want nothing in JSON mode", stderr.String())
+			}
+		})
+	}
+}
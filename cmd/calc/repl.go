@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+)
+
+// maxHistory caps how many lines are kept in memory and shown by "history".
+const maxHistory = 1000
+
+const replHelp = `enter an expression, or:
+  NAME = EXPR   assign a variable
+  ans           the last successful result
+  history       list previous inputs
+  !N            re-run history entry N
+  !!            re-run the previous input
+  vars          list variables
+  help          show this message
+  quit          leave the REPL
+`
+
+// repl evaluates one line at a time against a set of named variables.
+// Errors are printed with the same messages the BDD suites assert on,
+// e.g. "error: cannot divide by zero", and never end the session.
+type repl struct {
+	vars    map[string]int
+	history []string
+	record  io.Writer // receives each new history line; may be nil
+	out     io.Writer
+	prompt  string
+}
+
+func newREPL(out io.Writer, history []string, record io.Writer) *repl {
+	return &repl{
+		vars:    make(map[string]int),
+		history: history,
+		record:  record,
+		out:     out,
+	}
+}
+
+// run reads lines from in until EOF or "quit".
+func (r *repl) run(in io.Reader) error {
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(r.out, r.prompt)
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+		if quit := r.handle(scanner.Text()); quit {
+			return nil
+		}
+	}
+}
+
+// handle processes one input line and reports whether the session should end.
+func (r *repl) handle(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" {
+		return false
+	}
+
+	if strings.HasPrefix(line, "!") {
+		recalled, err := r.recall(line)
+		if err != nil {
+			fmt.Fprintf(r.out, "error: %v\n", err)
+			return false
+		}
+		fmt.Fprintln(r.out, recalled)
+		line = recalled
+	}
+
+	switch line {
+	case "quit", "exit":
+		return true
+	case "help":
+		fmt.Fprint(r.out, replHelp)
+		return false
+	case "history":
+		for i, entry := range r.history {
+			fmt.Fprintf(r.out, "%4d  %s\n", i+1, entry)
+		}
+		return false
+	case "vars":
+		r.printVars()
+		return false
+	}
+
+	r.remember(line)
+	value, err := r.evaluate(line)
+	if err != nil {
+		fmt.Fprintf(r.out, "error: %v\n", err)
+		return false
+	}
+	r.vars["ans"] = value
+	fmt.Fprintln(r.out, value)
+	return false
+}
+
+// evaluate runs an expression or an assignment.
+func (r *repl) evaluate(line string) (int, error) {
+	expr := line
+	name, rhs, isAssignment := strings.Cut(line, "=")
+	if isAssignment {
+		name = strings.TrimSpace(name)
+		if !calc.IsIdentifier(name) {
+			return 0, fmt.Errorf("invalid variable name %q", name)
+		}
+		if name == "ans" {
+			return 0, fmt.Errorf("cannot assign to ans")
+		}
+		expr = rhs
+	}
+
+	value, err := calc.EvalEnv(expr, r.vars)
+	if err != nil {
+		return 0, err
+	}
+	if isAssignment {
+		r.vars[name] = value
+	}
+	return value, nil
+}
+
+// recall expands "!!" and "!N" to a previous history entry.
+func (r *repl) recall(line string) (string, error) {
+	if len(r.history) == 0 {
+		return "", fmt.Errorf("history is empty")
+	}
+	if line == "!!" {
+		return r.history[len(r.history)-1], nil
+	}
+	n, err := strconv.Atoi(line[1:])
+	if err != nil || n < 1 || n > len(r.history) {
+		return "", fmt.Errorf("no history entry %s", line[1:])
+	}
+	return r.history[n-1], nil
+}
+
+func (r *repl) remember(line string) {
+	r.history = append(r.history, line)
+	if len(r.history) > maxHistory {
+		r.history = r.history[len(r.history)-maxHistory:]
+	}
+	if r.record != nil {
+		_, _ = fmt.Fprintln(r.record, line) // Error ignored - history is best effort
+	}
+}
+
+func (r *repl) printVars() {
+	names := make([]string, 0, len(r.vars))
+	for name := range r.vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(r.out, "%s = %d\n", name, r.vars[name])
+	}
+}
+
+// loadHistory reads up to maxHistory lines from path. A missing file is
+// an empty history. A file holding more lines is rewritten with just the
+// ones kept, so it does not grow without bound.
+func loadHistory(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) > maxHistory {
+		lines = lines[len(lines)-maxHistory:]
+		if err := writeHistory(path, lines); err != nil {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// writeHistory replaces the file at path with lines. The new contents go to
+// a temporary file first, so a failure leaves the old history intact.
+func writeHistory(path string, lines []string) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp) // Error ignored - the rename error is the one to report
+		return err
+	}
+	return nil
+}
+
+// runREPL starts an interactive session, persisting input to historyPath
+// unless it is empty.
+func runREPL(historyPath string, stdin io.Reader, stdout, stderr io.Writer) int {
+	var history []string
+	var record io.Writer
+	if historyPath != "" {
+		var err error
+		if history, err = loadHistory(historyPath); err != nil {
+			fmt.Fprintf(stderr, "calc: reading history: %v\n", err)
+			return exitCalc
+		}
+		f, err := os.OpenFile(historyPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+		if err != nil {
+			fmt.Fprintf(stderr, "calc: opening history: %v\n", err)
+			return exitCalc
+		}
+		defer func() { _ = f.Close() }()
+		record = f
+	}
+
+	r := newREPL(stdout, history, record)
+	if isTerminal(stdin) {
+		r.prompt = "calc> "
+	}
+	if err := r.run(stdin); err != nil {
+		fmt.Fprintf(stderr, "calc: %v\n", err)
+		return exitCalc
+	}
+	return exitOK
+}
+
+// isTerminal reports whether r is an interactive character device.
+func isTerminal(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// defaultHistoryPath returns ~/.calc_history, or "" if there is no home.
+func defaultHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".calc_history")
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestREPL_Session drives a whole session through the REPL.
+func TestREPL_Session(t *testing.T) {
+	input := strings.Join([]string{
+		"x = 5",
+		"x * 2",
+		"ans + 1",
+		"10 / 0",
+		"ans",
+		"y",
+		"2 +",
+		"ans = 3",
+		"2x = 1",
+		"vars",
+	}, "\n")
+
+	var out bytes.Buffer
+	r := newREPL(&out, nil, nil)
+	if err := r.run(strings.NewReader(input)); err != nil {
+		t.Fatalf("run unexpected error: %v", err)
+	}
+
+	expected := strings.Join([]string{
+		"5",
+		"10",
+		"11",
+		"error: cannot divide by zero",
+		"11",
+		`error: position 1: undefined variable "y"`,
+		`error: position 4: expected number, variable or "(", found end of input`,
+		"error: cannot assign to ans",
+		`error: invalid variable name "2x"`,
+		"ans = 11",
+		"x = 5",
+	}, "\n") + "\n"
+	if out.String() != expected {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), expected)
+	}
+}
+
+// TestREPL_HistoryRecall demonstrates "history", "!N" and "!!".
+func TestREPL_HistoryRecall(t *testing.T) {
+	var out, record bytes.Buffer
+	r := newREPL(&out, []string{"1 + 1"}, &record)
+
+	for _, line := range []string{"2 * 3", "history", "!1", "!!", "!9", "quit", "4 * 4"} {
+		if quit := r.handle(line); quit {
+			break
+		}
+	}
+
+	expected := strings.Join([]string{
+		"6",
+		"   1  1 + 1",
+		"   2  2 * 3",
+		"1 + 1",
+		"2",
+		"1 + 1",
+		"2",
+		"error: no history entry 9",
+	}, "\n") + "\n"
+	if out.String() != expected {
+		t.Errorf("output =\n%s\nwant\n%s", out.String(), expected)
+	}
+
+	// Only new evaluations are appended to the history file.
+	if record.String() != "2 * 3\n1 + 1\n1 + 1\n" {
+		t.Errorf("recorded history = %q", record.String())
+	}
+}
+
+// TestRun_REPL checks the history file survives between sessions.
+func TestRun_REPL(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history")
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"--history", historyPath, "repl"}, strings.NewReader("x = 4\nx * x\n"), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("first session exit code = %d; stderr: %s", code, stderr.String())
+	}
+	if stdout.String() != "4\n16\n" {
+		t.Errorf("first session output = %q", stdout.String())
+	}
+
+	stdout.Reset()
+	code = run([]string{"--history", historyPath, "repl"}, strings.NewReader("history\n!2\n"), &stdout, &stderr)
+	if code != exitOK {
+		t.Fatalf("second session exit code = %d; stderr: %s", code, stderr.String())
+	}
+	// Variables do not persist, so re-running "x * x" fails.
+	expected := "   1  x = 4\n   2  x * x\nx * x\nerror: position 1: undefined variable \"x\"\n"
+	if stdout.String() != expected {
+		t.Errorf("second session output = %q; want %q", stdout.String(), expected)
+	}
+
+	data, err := os.ReadFile(historyPath)
+	if err != nil {
+		t.Fatalf("reading history: %v", err)
+	}
+	if string(data) != "x = 4\nx * x\nx * x\n" {
+		t.Errorf("history file = %q", data)
+	}
+}
+
+// TestRun_REPLTrimsHistory checks that an oversized history file is cut
+// back to the last maxHistory lines when a session starts.
+func TestRun_REPLTrimsHistory(t *testing.T) {
+	historyPath := filepath.Join(t.TempDir(), "history")
+	var old strings.Builder
+	for i := 1; i <= maxHistory+5; i++ {
+		fmt.Fprintf(&old, "%d\n", i)
+	}
+	if err := os.WriteFile(historyPath, []byte(old.String()), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"--history", historyPath, "repl"}, strings.NewReader("7 * 6\n"), &stdout, &stderr); code != exitOK {
+		t.Fatalf("exit code = %d; stderr: %s", code, stderr.String())
+	}
+
+	data, err := os.ReadFile(historyPath)
+	if err != nil {
+		t.Fatalf("reading history: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != maxHistory+1 || lines[0] != "6" || lines[len(lines)-1] != "7 * 6" {
+		t.Errorf("history file has %d lines from %q to %q; want %d from \"6\" to \"7 * 6\"",
+			len(lines), lines[0], lines[len(lines)-1], maxHistory+1)
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc"
+	"github.com/lirany1/go-testing-framework-examples/01_builtin_testing/calc/httpapi"
+)
+
+// defaultAddr is where serve listens when no address is given.
+const defaultAddr = "localhost:8080"
+
+// runServe serves the HTTP API until the listener fails.
+func runServe(args []string, stderr io.Writer) int {
+	if len(args) > 1 {
+		fmt.Fprintln(stderr, "calc: serve takes at most one address")
+		fmt.Fprint(stderr, usage)
+		return exitUsage
+	}
+	addr := defaultAddr
+	if len(args) == 1 {
+		addr = args[0]
+	}
+
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           httpapi.NewHandler(calc.NewCalculator()),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	fmt.Fprintf(stderr, "calc: serving POST /calc on %s\n", addr)
+	if err := server.ListenAndServe(); err != nil {
+		fmt.Fprintf(stderr, "calc: %v\n", err)
+		return exitCalc
+	}
+	return exitOK
+}
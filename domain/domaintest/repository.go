@@ -0,0 +1,273 @@
+// Package domaintest provides a conformance suite for user repositories,
+// so every implementation is held to the same behaviour.
+package domaintest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
+)
+
+// UserRepository is the full repository interface, matching the one the
+// gomock example mocks.
+type UserRepository interface {
+	GetUser(id int) (*domain.User, error)
+	SaveUser(user *domain.User) error
+	DeleteUser(id int) error
+	ListUsers() ([]*domain.User, error)
+}
+
+// TestUserRepository runs the conformance suite. newRepo must return a new,
+// empty repository each time it is called.
+func TestUserRepository(t *testing.T, newRepo func(t *testing.T) UserRepository) {
+	t.Run("SaveAssignsIDs", func(t *testing.T) {
+		repo := newRepo(t)
+		alice := save(t, repo, "Alice", "alice@example.com")
+		bob := save(t, repo, "Bob", "bob@example.com")
+
+		if alice.ID <= 0 || bob.ID <= 0 || alice.ID == bob.ID {
+			t.Fatalf("assigned IDs %d and %d; want distinct positive IDs", alice.ID, bob.ID)
+		}
+		got, err := repo.GetUser(bob.ID)
+		if err != nil {
+			t.Fatalf("GetUser(%d) error = %v", bob.ID, err)
+		}
+		if *got != *bob {
+			t.Errorf("GetUser(%d) = %+v; want %+v", bob.ID, *got, *bob)
+		}
+	})
+
+	t.Run("SaveRejectsInvalidUser", func(t *testing.T) {
+		repo := newRepo(t)
+		user := &domain.User{Name: "", Email: "not-an-email"}
+		err := repo.SaveUser(user)
+
+		var ve *domain.ValidationError
+		if !errors.As(err, &ve) {
+			t.Fatalf("SaveUser() error = %v; want *domain.ValidationError", err)
+		}
+		if user.ID != 0 {
+			t.Errorf("SaveUser() assigned ID %d to an invalid user", user.ID)
+		}
+		expectLen(t, repo, 0)
+	})
+
+	t.Run("SaveUpdatesExistingUser", func(t *testing.T) {
+		repo := newRepo(t)
+		user := save(t, repo, "Alice", "alice@example.com")
+
+		update := &domain.User{ID: user.ID, Name: "Alice Smith", Email: "alice.smith@example.com"}
+		if err := repo.SaveUser(update); err != nil {
+			t.Fatalf("SaveUser(%+v) error = %v", *update, err)
+		}
+		got, err := repo.GetUser(user.ID)
+		if err != nil {
+			t.Fatalf("GetUser(%d) error = %v", user.ID, err)
+		}
+		if *got != *update {
+			t.Errorf("GetUser(%d) = %+v; want %+v", user.ID, *got, *update)
+		}
+		expectLen(t, repo, 1)
+	})
+
+	t.Run("DuplicateEmail", func(t *testing.T) {
+		repo := newRepo(t)
+		alice := save(t, repo, "Alice", "alice@example.com")
+		bob := save(t, repo, "Bob", "bob@example.com")
+
+		twin := &domain.User{Name: "Alice Again", Email: "alice@example.com"}
+		err := repo.SaveUser(twin)
+		var fe *domain.FieldError
+		if !errors.Is(err, domain.ErrDuplicateEmail) || !errors.As(err, &fe) || fe.Field != "email" {
+			t.Errorf("SaveUser() duplicate insert error = %v; want email %v", err, domain.ErrDuplicateEmail)
+		}
+		if twin.ID != 0 {
+			t.Errorf("SaveUser() assigned ID %d to a duplicate", twin.ID)
+		}
+
+		taken := &domain.User{ID: bob.ID, Name: "Bob", Email: alice.Email}
+		if err := repo.SaveUser(taken); !errors.Is(err, domain.ErrDuplicateEmail) {
+			t.Errorf("SaveUser() duplicate update error = %v; want %v", err, domain.ErrDuplicateEmail)
+		}
+		if got, err := repo.GetUser(bob.ID); err != nil || *got != *bob {
+			t.Errorf("GetUser(%d) after rejected update = %v, %v; want %+v", bob.ID, got, err, *bob)
+		}
+
+		// Keeping your own email is not a duplicate, and a deleted user's
+		// email is free again.
+		renamed := &domain.User{ID: alice.ID, Name: "Alice Smith", Email: alice.Email}
+		if err := repo.SaveUser(renamed); err != nil {
+			t.Errorf("SaveUser() keeping own email error = %v", err)
+		}
+		if err := repo.DeleteUser(alice.ID); err != nil {
+			t.Fatalf("DeleteUser(%d) error = %v", alice.ID, err)
+		}
+		save(t, repo, "Alice Again", "alice@example.com")
+		expectLen(t, repo, 2)
+	})
+
+	t.Run("SaveUnknownIDIsNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		err := repo.SaveUser(&domain.User{ID: 42, Name: "Ghost", Email: "ghost@example.com"})
+		if !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("SaveUser() error = %v; want %v", err, domain.ErrNotFound)
+		}
+		expectLen(t, repo, 0)
+	})
+
+	t.Run("GetUnknownIDIsNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		user, err := repo.GetUser(1)
+		if !errors.Is(err, domain.ErrNotFound) || user != nil {
+			t.Errorf("GetUser(1) = %v, %v; want nil, %v", user, err, domain.ErrNotFound)
+		}
+	})
+
+	t.Run("ReturnsCopies", func(t *testing.T) {
+		repo := newRepo(t)
+		user := save(t, repo, "Alice", "alice@example.com")
+		user.Name = "changed after save"
+
+		got, err := repo.GetUser(user.ID)
+		if err != nil {
+			t.Fatalf("GetUser(%d) error = %v", user.ID, err)
+		}
+		got.Name = "changed after get"
+
+		users, err := repo.ListUsers()
+		if err != nil {
+			t.Fatalf("ListUsers() error = %v", err)
+		}
+		users[0].Name = "changed after list"
+
+		got, err = repo.GetUser(user.ID)
+		if err != nil {
+			t.Fatalf("GetUser(%d) error = %v", user.ID, err)
+		}
+		if got.Name != "Alice" {
+			t.Errorf("stored name = %q; want %q", got.Name, "Alice")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+		alice := save(t, repo, "Alice", "alice@example.com")
+		bob := save(t, repo, "Bob", "bob@example.com")
+
+		if err := repo.DeleteUser(alice.ID); err != nil {
+			t.Fatalf("DeleteUser(%d) error = %v", alice.ID, err)
+		}
+		if _, err := repo.GetUser(alice.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("GetUser(%d) after delete error = %v; want %v", alice.ID, err, domain.ErrNotFound)
+		}
+		if err := repo.DeleteUser(alice.ID); !errors.Is(err, domain.ErrNotFound) {
+			t.Errorf("second DeleteUser(%d) error = %v; want %v", alice.ID, err, domain.ErrNotFound)
+		}
+		expectLen(t, repo, 1)
+
+		carol := save(t, repo, "Carol", "carol@example.com")
+		if carol.ID == alice.ID || carol.ID == bob.ID {
+			t.Errorf("new user got ID %d, which was already used", carol.ID)
+		}
+	})
+
+	t.Run("ListOrderedByID", func(t *testing.T) {
+		repo := newRepo(t)
+		users, err := repo.ListUsers()
+		if err != nil || users == nil || len(users) != 0 {
+			t.Fatalf("ListUsers() on empty repository = %v, %v; want empty, nil", users, err)
+		}
+
+		var want []domain.User
+		for _, name := range []string{"Alice", "Bob", "Carol", "Dave"} {
+			want = append(want, *save(t, repo, name, fmt.Sprintf("%s@example.com", name)))
+		}
+		users, err = repo.ListUsers()
+		if err != nil {
+			t.Fatalf("ListUsers() error = %v", err)
+		}
+		if len(users) != len(want) {
+			t.Fatalf("ListUsers() returned %d users; want %d", len(users), len(want))
+		}
+		for i, user := range users {
+			if *user != want[i] {
+				t.Errorf("ListUsers()[%d] = %+v; want %+v", i, *user, want[i])
+			}
+		}
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		repo := newRepo(t)
+		const writers, perWriter = 8, 20
+
+		var wg sync.WaitGroup
+		ids := make(chan int, writers*perWriter)
+		for w := 0; w < writers; w++ {
+			wg.Add(2)
+			go func(w int) {
+				defer wg.Done()
+				for i := 0; i < perWriter; i++ {
+					user := &domain.User{Name: "User", Email: fmt.Sprintf("user%d.%d@example.com", w, i)}
+					if err := repo.SaveUser(user); err != nil {
+						t.Errorf("SaveUser() error = %v", err)
+						return
+					}
+					ids <- user.ID
+					if i%2 == 1 {
+						user.Name = "Renamed"
+						if err := repo.SaveUser(user); err != nil {
+							t.Errorf("SaveUser(%d) update error = %v", user.ID, err)
+						}
+					}
+				}
+			}(w)
+			go func() {
+				defer wg.Done()
+				for i := 0; i < perWriter; i++ {
+					if _, err := repo.ListUsers(); err != nil {
+						t.Errorf("ListUsers() error = %v", err)
+					}
+					if _, err := repo.GetUser(1); err != nil && !errors.Is(err, domain.ErrNotFound) {
+						t.Errorf("GetUser(1) error = %v", err)
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		close(ids)
+
+		seen := make(map[int]bool)
+		for id := range ids {
+			if seen[id] {
+				t.Errorf("ID %d assigned twice", id)
+			}
+			seen[id] = true
+		}
+		expectLen(t, repo, writers*perWriter)
+	})
+}
+
+// save stores a new user, failing the test on error.
+func save(t *testing.T, repo UserRepository, name, email string) *domain.User {
+	t.Helper()
+	user := &domain.User{Name: name, Email: email}
+	if err := repo.SaveUser(user); err != nil {
+		t.Fatalf("SaveUser(%+v) error = %v", *user, err)
+	}
+	return user
+}
+
+// expectLen checks how many users repo lists.
+func expectLen(t *testing.T, repo UserRepository, want int) {
+	t.Helper()
+	users, err := repo.ListUsers()
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if len(users) != want {
+		t.Errorf("ListUsers() returned %d users; want %d", len(users), want)
+	}
+}
@@ -0,0 +1,105 @@
+package domain
+
+import "strings"
+
+// Length limits for email addresses from RFC 5321.
+const (
+	maxEmailLength  = 254
+	maxLocalLength  = 64
+	maxDomainLength = 253
+	maxLabelLength  = 63
+)
+
+// NormalizeEmail trims surrounding spaces and lowercases the domain, which
+// is case-insensitive. The local part is kept as given, since RFC 5321
+// lets mail servers treat it as case-sensitive.
+func NormalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+	return email[:at+1] + strings.ToLower(email[at+1:])
+}
+
+// emailDomain returns the part of email after the last "@", or "".
+func emailDomain(email string) string {
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return ""
+	}
+	return email[at+1:]
+}
+
+// ValidateEmail checks that email is a plain RFC 5322 addr-spec with a
+// dot-atom local part and a multi-label DNS domain, such as
+// "jane.doe+tag@example.co.uk". Quoted local parts, comments, display
+// names and IP-literal domains are deliberately rejected. It returns
+// ErrRequired, ErrTooLong or ErrInvalidEmail.
+func ValidateEmail(email string) error {
+	if email == "" {
+		return ErrRequired
+	}
+	if len(email) > maxEmailLength {
+		return ErrTooLong
+	}
+
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return ErrInvalidEmail
+	}
+	if !validLocalPart(email[:at]) || !validDomain(emailDomain(email)) {
+		return ErrInvalidEmail
+	}
+	return nil
+}
+
+// validLocalPart checks a dot-atom: atext runs separated by single dots.
+func validLocalPart(local string) bool {
+	if local == "" || len(local) > maxLocalLength {
+		return false
+	}
+	for _, atom := range strings.Split(local, ".") {
+		if atom == "" {
+			return false
+		}
+		for i := 0; i < len(atom); i++ {
+			if !isAtext(atom[i]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func isAtext(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	}
+	return strings.IndexByte("!#$%&'*+/=?^_`{|}~-", c) >= 0
+}
+
+// validDomain checks for at least two DNS labels of letters, digits and
+// inner hyphens.
+func validDomain(domain string) bool {
+	if len(domain) > maxDomainLength {
+		return false
+	}
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > maxLabelLength || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for i := 0; i < len(label); i++ {
+			c := label[i]
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
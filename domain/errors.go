@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Error represents a simple error type.
+type Error string
+
+func (e Error) Error() string {
+	return string(e)
+}
+
+// Reasons a field can fail validation. FieldError wraps them, so callers
+// can test for them with errors.Is.
+const (
+	ErrRequired     = Error("is required")
+	ErrTooLong      = Error("is too long")
+	ErrInvalidEmail = Error("is not a valid email address")
+
+	// ErrDuplicateEmail is reported by the repositories when the email
+	// belongs to another stored user.
+	ErrDuplicateEmail = Error("is already registered")
+)
+
+// ErrNotFound is returned by the repositories for an ID that is not stored.
+const ErrNotFound = Error("user not found")
+
+// FieldError reports why a single field failed validation.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError lists every field that failed validation, in the order
+// the fields were checked.
+type ValidationError struct {
+	Fields []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return "invalid user: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the field errors, so errors.Is and errors.As see them.
+func (e *ValidationError) Unwrap() []error {
+	errs := make([]error, len(e.Fields))
+	for i, f := range e.Fields {
+		errs[i] = f
+	}
+	return errs
+}
+
+// Field returns the error for the named field, or nil.
+func (e *ValidationError) Field(name string) *FieldError {
+	for _, f := range e.Fields {
+		if f.Field == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// check records err against field if it is not nil.
+func (e *ValidationError) check(field string, err error) {
+	if err != nil {
+		e.Fields = append(e.Fields, &FieldError{Field: field, Err: err})
+	}
+}
+
+// err returns e as an error if any field failed, or nil.
+func (e *ValidationError) err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
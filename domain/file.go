@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// ErrCorrupt is returned by OpenFileUserRepository when the data file does
+// not match its checksum or cannot be decoded.
+const ErrCorrupt = Error("user file is corrupt")
+
+// ErrLocked is returned by a FileUserRepository write when a lock file it
+// did not create is present. That only happens when the file is shared,
+// which is unsupported, so it is a hint rather than a guarantee.
+const ErrLocked = Error("user file has a write in progress")
+
+// FileUserRepository keeps users in memory and persists every change to a
+// JSON file, so small deployments need no database. It implements the same
+// interfaces as MemoryUserRepository and is safe for concurrent use within
+// one process; only one process may use a file at a time.
+//
+// Writes are atomic: the new contents go to PATH.tmp, which is synced and
+// renamed over PATH, so a crash leaves either the old or the new file. The
+// lock file PATH.lock exists only while a write is in progress, so finding
+// it on open marks a crashed write, and the leftover temporary file is
+// discarded. The lock is not a mutex between processes: opening a file
+// that another process is writing removes that write's lock and temporary
+// file. The file holds a SHA-256 checksum of its contents, which is
+// verified on open to detect corruption.
+type FileUserRepository struct {
+	path string
+
+	mu       sync.RWMutex
+	table    table
+	leftLock bool // the lock file is ours, left by a failed removal
+}
+
+// fileEnvelope is the on-disk format. Checksum covers the exact bytes of
+// Data.
+type fileEnvelope struct {
+	Checksum string          `json:"checksum"`
+	Data     json.RawMessage `json:"data"`
+}
+
+type fileData struct {
+	NextID int    `json:"next_id"`
+	Users  []User `json:"users"`
+}
+
+// OpenFileUserRepository loads the users stored at path, or starts empty if
+// the file does not exist yet. It returns an error wrapping ErrCorrupt if
+// the file is damaged.
+func OpenFileUserRepository(path string) (*FileUserRepository, error) {
+	r := &FileUserRepository{path: path, table: newTable()}
+	if err := r.recover(); err != nil {
+		return nil, err
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Path returns the data file's path.
+func (r *FileUserRepository) Path() string {
+	return r.path
+}
+
+// GetUser returns a copy of the user with the given ID, or an error
+// wrapping ErrNotFound.
+func (r *FileUserRepository) GetUser(id int) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.table.get(id)
+}
+
+// SaveUser validates user and stores a copy of it, with the same ID rules
+// as MemoryUserRepository.SaveUser, including unique emails. If the file
+// cannot be written, the repository and user are left unchanged.
+func (r *FileUserRepository) SaveUser(user *User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := user.ID
+	err := r.update(func(t *table) error { return t.save(user) })
+	if err != nil {
+		user.ID = id
+	}
+	return err
+}
+
+// DeleteUser removes the user with the given ID, or returns an error
+// wrapping ErrNotFound. Deleted IDs are never reused, even after reopening.
+func (r *FileUserRepository) DeleteUser(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.update(func(t *table) error { return t.delete(id) })
+}
+
+// ListUsers returns copies of all users, ordered by ID.
+func (r *FileUserRepository) ListUsers() ([]*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.table.list(), nil
+}
+
+// update applies fn to a copy of the table and commits the result.
+func (r *FileUserRepository) update(fn func(t *table) error) error {
+	next := r.table.clone()
+	if err := fn(&next); err != nil {
+		return err
+	}
+	return r.commit(next)
+}
+
+func (r *FileUserRepository) lockPath() string { return r.path + ".lock" }
+func (r *FileUserRepository) tempPath() string { return r.path + ".tmp" }
+
+// recover cleans up after a writer that crashed while holding the lock.
+// Since only one process may use the file, any lock found here is assumed
+// to be stale. The data file itself is never partially written, so only
+// the temporary file needs discarding; load then verifies what is left.
+func (r *FileUserRepository) recover() error {
+	if _, err := os.Stat(r.lockPath()); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := os.Remove(r.tempPath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return os.Remove(r.lockPath())
+}
+
+// load reads and verifies the data file.
+func (r *FileUserRepository) load() error {
+	raw, err := os.ReadFile(r.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var env fileEnvelope
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return r.corrupt(err.Error())
+	}
+	if env.Checksum != checksum(env.Data) {
+		return r.corrupt("checksum mismatch")
+	}
+	var data fileData
+	if err := json.Unmarshal(env.Data, &data); err != nil {
+		return r.corrupt(err.Error())
+	}
+
+	if data.NextID < 1 {
+		return r.corrupt(fmt.Sprintf("invalid next ID %d", data.NextID))
+	}
+	t := newTable()
+	t.nextID = data.NextID
+	for _, user := range data.Users {
+		if _, dup := t.users[user.ID]; dup || user.ID <= 0 || user.ID >= t.nextID {
+			return r.corrupt(fmt.Sprintf("invalid user ID %d", user.ID))
+		}
+		if err := user.Validate(); err != nil {
+			return r.corrupt(fmt.Sprintf("user %d: %v", user.ID, err))
+		}
+		if _, dup := t.byEmail[user.Email]; dup {
+			return r.corrupt(fmt.Sprintf("duplicate email %q", user.Email))
+		}
+		t.users[user.ID] = user
+		t.byEmail[user.Email] = user.ID
+	}
+	r.table = t
+	return nil
+}
+
+// commit atomically replaces the data file with the contents of t, and
+// then the in-memory table, so both stay unchanged if the write fails.
+func (r *FileUserRepository) commit(t table) error {
+	data := fileData{NextID: t.nextID, Users: make([]User, 0, len(t.users))}
+	for _, user := range t.list() {
+		data.Users = append(data.Users, *user)
+	}
+	body, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	out, err := json.Marshal(fileEnvelope{Checksum: checksum(body), Data: body})
+	if err != nil {
+		return err
+	}
+
+	if err := r.lock(); err != nil {
+		return err
+	}
+	if err := r.replace(out); err != nil {
+		// Best effort: the old data file is still intact either way.
+		_ = os.Remove(r.tempPath())
+		r.unlock()
+		return err
+	}
+	r.table = t
+	r.unlock()
+	return nil
+}
+
+// lock creates the lock file. A lock file this repository failed to
+// remove after an earlier write is reused rather than reported as locked.
+func (r *FileUserRepository) lock() error {
+	f, err := os.OpenFile(r.lockPath(), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	switch {
+	case errors.Is(err, fs.ErrExist) && r.leftLock:
+		return nil
+	case errors.Is(err, fs.ErrExist):
+		return fmt.Errorf("%s: %w", r.path, ErrLocked)
+	case err != nil:
+		return err
+	}
+	return f.Close()
+}
+
+// unlock removes the lock file. By then the write has either been renamed
+// into place or abandoned, so a failure is not reported; the leftover lock
+// is remembered as ours so that it does not block later writes.
+func (r *FileUserRepository) unlock() {
+	err := os.Remove(r.lockPath())
+	r.leftLock = err != nil && !errors.Is(err, fs.ErrNotExist)
+}
+
+// replace writes contents to the temporary file and renames it over the
+// data file, syncing both so the change survives a power failure.
+func (r *FileUserRepository) replace(contents []byte) error {
+	f, err := os.OpenFile(r.tempPath(), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(contents); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(r.tempPath(), r.path); err != nil {
+		return err
+	}
+	syncDir(filepath.Dir(r.path))
+	return nil
+}
+
+// syncDir flushes a directory entry change such as a rename. Not every
+// platform supports syncing directories, and the rename has already
+// happened, so this is best effort.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+}
+
+func (r *FileUserRepository) corrupt(reason string) error {
+	return fmt.Errorf("%s: %w: %s", r.path, ErrCorrupt, reason)
+}
+
+// checksum returns the SHA-256 of b as "sha256:" followed by hex digits.
+func checksum(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
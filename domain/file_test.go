@@ -0,0 +1,221 @@
+package domain_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
+	"github.com/lirany1/go-testing-framework-examples/domain/domaintest"
+)
+
+// TestFileUserRepository runs the shared repository conformance suite.
+func TestFileUserRepository(t *testing.T) {
+	domaintest.TestUserRepository(t, func(t *testing.T) domaintest.UserRepository {
+		return openFile(t, filepath.Join(t.TempDir(), "users.json"))
+	})
+}
+
+// TestFileUserRepository_Reopen demonstrates that data, and the next ID,
+// survive a restart.
+func TestFileUserRepository_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	repo := openFile(t, path)
+	alice := &domain.User{Name: "Alice", Email: "alice@example.com"}
+	bob := &domain.User{Name: "Bob", Email: "bob@example.com"}
+	for _, user := range []*domain.User{alice, bob} {
+		if err := repo.SaveUser(user); err != nil {
+			t.Fatalf("SaveUser() error = %v", err)
+		}
+	}
+	if err := repo.DeleteUser(bob.ID); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+
+	repo = openFile(t, path)
+	users, err := repo.ListUsers()
+	if err != nil || len(users) != 1 || *users[0] != *alice {
+		t.Fatalf("ListUsers() after reopen = %v, %v; want only %+v", users, err, *alice)
+	}
+
+	carol := &domain.User{Name: "Carol", Email: "carol@example.com"}
+	if err := repo.SaveUser(carol); err != nil {
+		t.Fatalf("SaveUser() error = %v", err)
+	}
+	if carol.ID <= bob.ID {
+		t.Errorf("new user got ID %d after reopen; want more than %d", carol.ID, bob.ID)
+	}
+	expectNoLeftovers(t, path)
+}
+
+// TestFileUserRepository_CrashMidWrite simulates a writer that died after
+// taking the lock and writing part of the temporary file.
+func TestFileUserRepository_CrashMidWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	repo := openFile(t, path)
+	alice := &domain.User{Name: "Alice", Email: "alice@example.com"}
+	if err := repo.SaveUser(alice); err != nil {
+		t.Fatalf("SaveUser() error = %v", err)
+	}
+
+	writeFile(t, path+".lock", "")
+	writeFile(t, path+".tmp", `{"checksum":"sha256:0123","data":{"next_id":3,"us`)
+
+	repo = openFile(t, path)
+	users, err := repo.ListUsers()
+	if err != nil || len(users) != 1 || *users[0] != *alice {
+		t.Fatalf("ListUsers() after crash = %v, %v; want only %+v", users, err, *alice)
+	}
+	expectNoLeftovers(t, path)
+
+	if err := repo.SaveUser(&domain.User{Name: "Bob", Email: "bob@example.com"}); err != nil {
+		t.Errorf("SaveUser() after recovery error = %v", err)
+	}
+}
+
+// TestFileUserRepository_FailedWrite checks that a write that cannot
+// complete leaves both the file and the repository unchanged.
+func TestFileUserRepository_FailedWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	repo := openFile(t, path)
+	alice := &domain.User{Name: "Alice", Email: "alice@example.com"}
+	if err := repo.SaveUser(alice); err != nil {
+		t.Fatalf("SaveUser() error = %v", err)
+	}
+	before := readFile(t, path)
+
+	// A non-empty directory in the way of the temporary file makes every
+	// write fail, and survives the failed write's cleanup.
+	if err := os.Mkdir(path+".tmp", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(path+".tmp", "blocker"), "")
+	bob := &domain.User{Name: "Bob", Email: "bob@example.com"}
+	if err := repo.SaveUser(bob); err == nil {
+		t.Fatal("SaveUser() succeeded with the temporary file blocked")
+	}
+	if bob.ID != 0 {
+		t.Errorf("failed SaveUser() left ID %d on the user", bob.ID)
+	}
+	if err := repo.DeleteUser(alice.ID); err == nil {
+		t.Fatal("DeleteUser() succeeded with the temporary file blocked")
+	}
+
+	if users, _ := repo.ListUsers(); len(users) != 1 || *users[0] != *alice {
+		t.Errorf("ListUsers() after failed writes = %v; want only %+v", users, *alice)
+	}
+	if after := readFile(t, path); after != before {
+		t.Errorf("data file changed by failed writes:\n%s\nwant:\n%s", after, before)
+	}
+	if _, err := os.Stat(path + ".lock"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("lock file left behind after a failed write: %v", err)
+	}
+}
+
+// TestFileUserRepository_Locked checks that a write is refused while a
+// lock file this repository did not create is present.
+func TestFileUserRepository_Locked(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	repo := openFile(t, path)
+	writeFile(t, path+".lock", "")
+
+	err := repo.SaveUser(&domain.User{Name: "Alice", Email: "alice@example.com"})
+	if !errors.Is(err, domain.ErrLocked) {
+		t.Errorf("SaveUser() error = %v; want %v", err, domain.ErrLocked)
+	}
+	if users, _ := repo.ListUsers(); len(users) != 0 {
+		t.Errorf("ListUsers() = %v; want none", users)
+	}
+}
+
+// TestFileUserRepository_Corrupt demonstrates detecting damaged files.
+func TestFileUserRepository_Corrupt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.json")
+	repo := openFile(t, path)
+	if err := repo.SaveUser(&domain.User{Name: "Alice", Email: "alice@example.com"}); err != nil {
+		t.Fatalf("SaveUser() error = %v", err)
+	}
+	good := readFile(t, path)
+
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{"empty", ""},
+		{"truncated", good[:len(good)/2]},
+		{"edited", strings.Replace(good, "Alice", "Alicia", 1)},
+		{"bad checksum", strings.Replace(good, "sha256:", "sha256:00", 1)},
+		{"not an object", "[]"},
+		{"missing next ID", withChecksum(`{"users":[]}`)},
+		{"zero next ID", withChecksum(`{"next_id":0,"users":[]}`)},
+		{"invalid email", withChecksum(`{"next_id":2,"users":[{"id":1,"name":"A","email":"not-an-email"}]}`)},
+		{"missing name", withChecksum(`{"next_id":2,"users":[{"id":1,"name":"","email":"a@example.com"}]}`)},
+		{"ID beyond next ID", withChecksum(`{"next_id":1,"users":[{"id":1,"name":"A","email":"a@example.com"}]}`)},
+		{"duplicate email", withChecksum(`{"next_id":3,"users":[{"id":1,"name":"A","email":"a@example.com"},{"id":2,"name":"B","email":"a@example.com"}]}`)},
+		{"duplicate ID", withChecksum(`{"next_id":3,"users":[{"id":2,"name":"A","email":"a@example.com"},{"id":2,"name":"B","email":"b@example.com"}]}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeFile(t, path, tt.contents)
+			repo, err := domain.OpenFileUserRepository(path)
+			if !errors.Is(err, domain.ErrCorrupt) || repo != nil {
+				t.Errorf("OpenFileUserRepository() = %v, %v; want nil, %v", repo, err, domain.ErrCorrupt)
+			}
+		})
+	}
+
+	t.Run("valid hand-written file", func(t *testing.T) {
+		writeFile(t, path, withChecksum(`{"next_id":8,"users":[{"id":7,"name":"A","email":"a@example.com"}]}`))
+		repo := openFile(t, path)
+		if user, err := repo.GetUser(7); err != nil || user.Name != "A" {
+			t.Errorf("GetUser(7) = %v, %v; want user A", user, err)
+		}
+	})
+}
+
+// withChecksum wraps data in the on-disk envelope with a correct checksum.
+func withChecksum(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return `{"checksum":"sha256:` + hex.EncodeToString(sum[:]) + `","data":` + data + `}`
+}
+
+func openFile(t *testing.T, path string) *domain.FileUserRepository {
+	t.Helper()
+	repo, err := domain.OpenFileUserRepository(path)
+	if err != nil {
+		t.Fatalf("OpenFileUserRepository(%q) error = %v", path, err)
+	}
+	return repo
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
+
+func writeFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// expectNoLeftovers checks that neither the lock nor the temporary file
+// remains next to path.
+func expectNoLeftovers(t *testing.T, path string) {
+	t.Helper()
+	for _, name := range []string{path + ".lock", path + ".tmp"} {
+		if _, err := os.Stat(name); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("%s left behind: %v", filepath.Base(name), err)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// MemoryUserRepository keeps users in memory. It implements the
+// UserRepository interfaces of the testify and gomock examples and is safe
+// for concurrent use. Users are copied on the way in and out, so callers
+// can never modify stored state through a pointer.
+type MemoryUserRepository struct {
+	mu    sync.RWMutex
+	table table
+}
+
+// NewMemoryUserRepository creates an empty repository. IDs start at 1.
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{table: newTable()}
+}
+
+// GetUser returns a copy of the user with the given ID, or an error
+// wrapping ErrNotFound.
+func (r *MemoryUserRepository) GetUser(id int) (*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.table.get(id)
+}
+
+// SaveUser validates user and stores a copy of it. A user with a zero ID
+// is inserted and user.ID is set to the newly assigned ID; any other ID
+// replaces the stored user, returning an error wrapping ErrNotFound if
+// there is none. Emails are unique: an email that belongs to another user
+// is reported as a *FieldError wrapping ErrDuplicateEmail.
+func (r *MemoryUserRepository) SaveUser(user *User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.table.save(user)
+}
+
+// DeleteUser removes the user with the given ID, or returns an error
+// wrapping ErrNotFound. Deleted IDs are never reused.
+func (r *MemoryUserRepository) DeleteUser(id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.table.delete(id)
+}
+
+// ListUsers returns copies of all users, ordered by ID.
+func (r *MemoryUserRepository) ListUsers() ([]*User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.table.list(), nil
+}
+
+// table is the bookkeeping shared by the repositories that hold every
+// user in memory. It is not safe for concurrent use.
+type table struct {
+	users   map[int]User
+	byEmail map[string]int // email -> ID
+	nextID  int
+}
+
+func newTable() table {
+	return table{users: make(map[int]User), byEmail: make(map[string]int), nextID: 1}
+}
+
+func (t *table) get(id int) (*User, error) {
+	user, ok := t.users[id]
+	if !ok {
+		return nil, notFound(id)
+	}
+	return &user, nil
+}
+
+// save inserts user, assigning its ID, if the ID is zero and replaces the
+// stored user otherwise.
+func (t *table) save(user *User) error {
+	if user.ID != 0 {
+		if _, ok := t.users[user.ID]; !ok {
+			return notFound(user.ID)
+		}
+	}
+	if id, taken := t.byEmail[user.Email]; taken && id != user.ID {
+		return &FieldError{Field: "email", Err: ErrDuplicateEmail}
+	}
+
+	if user.ID == 0 {
+		user.ID = t.nextID
+		t.nextID++
+	} else {
+		delete(t.byEmail, t.users[user.ID].Email)
+	}
+	t.users[user.ID] = *user
+	t.byEmail[user.Email] = user.ID
+	return nil
+}
+
+func (t *table) delete(id int) error {
+	user, ok := t.users[id]
+	if !ok {
+		return notFound(id)
+	}
+	delete(t.users, id)
+	delete(t.byEmail, user.Email)
+	return nil
+}
+
+// list returns copies of all users, ordered by ID.
+func (t *table) list() []*User {
+	users := make([]*User, 0, len(t.users))
+	for _, user := range t.users {
+		users = append(users, &user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users
+}
+
+// clone returns an independent copy of t.
+func (t *table) clone() table {
+	c := table{
+		users:   make(map[int]User, len(t.users)),
+		byEmail: make(map[string]int, len(t.byEmail)),
+		nextID:  t.nextID,
+	}
+	for id, user := range t.users {
+		c.users[id] = user
+	}
+	for email, id := range t.byEmail {
+		c.byEmail[email] = id
+	}
+	return c
+}
+
+// notFound reports a missing ID.
+func notFound(id int) error {
+	return fmt.Errorf("user %d: %w", id, ErrNotFound)
+}
@@ -0,0 +1,15 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
+	"github.com/lirany1/go-testing-framework-examples/domain/domaintest"
+)
+
+// TestMemoryUserRepository runs the shared repository conformance suite.
+func TestMemoryUserRepository(t *testing.T) {
+	domaintest.TestUserRepository(t, func(t *testing.T) domaintest.UserRepository {
+		return domain.NewMemoryUserRepository()
+	})
+}
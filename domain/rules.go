@@ -0,0 +1,33 @@
+package domain
+
+import "strings"
+
+// ErrBlockedDomain is reported for an email address whose domain is
+// rejected by a BlockedDomains rule.
+const ErrBlockedDomain = Error("uses a blocked domain")
+
+// Rule is an extra validation check run after the built-in ones. It
+// returns a *FieldError for the offending field, or nil if u passes.
+type Rule func(u *User) *FieldError
+
+// BlockedDomains rejects email addresses at any of the given domains or
+// their subdomains, e.g. "mailinator.com" also blocks "eu.mailinator.com".
+// Domains are compared case-insensitively.
+func BlockedDomains(domains ...string) Rule {
+	blocked := make([]string, len(domains))
+	for i, d := range domains {
+		blocked[i] = strings.ToLower(strings.TrimSpace(d))
+	}
+	return func(u *User) *FieldError {
+		domain := strings.ToLower(emailDomain(u.Email))
+		if domain == "" {
+			return nil // a missing domain is ValidateEmail's concern
+		}
+		for _, b := range blocked {
+			if domain == b || strings.HasSuffix(domain, "."+b) {
+				return &FieldError{Field: "email", Err: ErrBlockedDomain}
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,141 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// migrationFS holds the schema migrations. Each file is named
+// NNNN_description.sql, and the numbers run from 1 without gaps.
+//
+//go:embed migrations/*.sql
+var migrationFS embed.FS
+
+// SchemaTooNewError is returned by Migrate when the database has been
+// migrated by a newer version of this package.
+type SchemaTooNewError struct {
+	Have, Want int
+}
+
+func (e *SchemaTooNewError) Error() string {
+	return fmt.Sprintf("database schema version %d is newer than the latest known version %d", e.Have, e.Want)
+}
+
+// migration is one versioned schema change.
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrations returns the embedded migrations in version order.
+func migrations() ([]migration, error) {
+	files, err := fs.Glob(migrationFS, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var ms []migration
+	for _, file := range files {
+		name := path.Base(file)
+		prefix, _, ok := strings.Cut(name, "_")
+		version, err := strconv.Atoi(prefix)
+		if !ok || err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration %s: name must start with a positive version number", name)
+		}
+		body, err := migrationFS.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		ms = append(ms, migration{version: version, name: strings.TrimSuffix(name, ".sql"), sql: string(body)})
+	}
+
+	sort.Slice(ms, func(i, j int) bool { return ms[i].version < ms[j].version })
+	for i, m := range ms {
+		if m.version != i+1 {
+			return nil, fmt.Errorf("migration %s: want version %d", m.name, i+1)
+		}
+	}
+	return ms, nil
+}
+
+// LatestVersion returns the schema version Migrate brings a database to.
+func LatestVersion() int {
+	ms, err := migrations()
+	if err != nil {
+		panic(err) // the migrations are embedded, so this is a build problem
+	}
+	return len(ms)
+}
+
+// SchemaVersion returns the version of the last migration applied to db,
+// or 0 if there is none.
+func SchemaVersion(ctx context.Context, db *sql.DB) (int, error) {
+	if err := createMigrationsTable(ctx, db); err != nil {
+		return 0, err
+	}
+	var version int
+	err := db.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// Migrate applies every migration newer than the database's schema
+// version, each in its own transaction, and returns how many it applied.
+// It returns a *SchemaTooNewError if the database is ahead of this code.
+func Migrate(ctx context.Context, db *sql.DB) (int, error) {
+	ms, err := migrations()
+	if err != nil {
+		return 0, err
+	}
+	current, err := SchemaVersion(ctx, db)
+	if err != nil {
+		return 0, err
+	}
+	if current > len(ms) {
+		return 0, &SchemaTooNewError{Have: current, Want: len(ms)}
+	}
+
+	for _, m := range ms[current:] {
+		if err := apply(ctx, db, m); err != nil {
+			return m.version - current - 1, fmt.Errorf("migration %s: %w", m.name, err)
+		}
+	}
+	return len(ms) - current, nil
+}
+
+func createMigrationsTable(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    INTEGER PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TEXT NOT NULL
+	)`)
+	return err
+}
+
+// apply runs one migration and records it, atomically.
+func apply(ctx context.Context, db *sql.DB, m migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }() // no-op after Commit
+
+	if _, err := tx.ExecContext(ctx, m.sql); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)`,
+		m.version, m.name, time.Now().UTC().Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
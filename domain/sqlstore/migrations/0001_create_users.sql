@@ -0,0 +1,7 @@
+-- AUTOINCREMENT keeps deleted IDs from being reused, as the other
+-- repositories guarantee.
+CREATE TABLE users (
+    id    INTEGER PRIMARY KEY AUTOINCREMENT,
+    name  TEXT NOT NULL,
+    email TEXT NOT NULL
+);
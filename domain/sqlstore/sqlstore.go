@@ -0,0 +1,194 @@
+// Package sqlstore implements the user repository on database/sql. It uses
+// modernc.org/sqlite, a pure-Go SQLite, so it runs anywhere Go does with
+// no external database and no cgo. Importing the package registers the
+// "sqlite" driver.
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
+)
+
+// UserRepository stores users in a SQL database. It implements the same
+// interfaces as domain.MemoryUserRepository, with the same behaviour, and
+// is safe for concurrent use.
+type UserRepository struct {
+	db      *sql.DB
+	closeDB bool
+
+	get, insert, update, delete, list *sql.Stmt
+}
+
+// Open opens, or creates, the SQLite database at path and returns a
+// repository on it; ":memory:" gives a private in-memory database. The
+// repository owns the database and closes it in Close.
+func Open(path string) (*UserRepository, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite allows one writer at a time, and every connection to
+	// ":memory:" is a separate database, so use a single connection.
+	db.SetMaxOpenConns(1)
+
+	r, err := New(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	r.closeDB = true
+	return r, nil
+}
+
+// New migrates db to the latest schema and prepares the repository's
+// statements. The caller keeps ownership of db.
+func New(db *sql.DB) (*UserRepository, error) {
+	ctx := context.Background()
+	if _, err := Migrate(ctx, db); err != nil {
+		return nil, err
+	}
+
+	r := &UserRepository{db: db}
+	stmts := []struct {
+		stmt  **sql.Stmt
+		query string
+	}{
+		{&r.get, `SELECT id, name, email FROM users WHERE id = ?`},
+		{&r.insert, `INSERT INTO users (name, email) VALUES (?, ?)`},
+		{&r.update, `UPDATE users SET name = ?, email = ? WHERE id = ?`},
+		{&r.delete, `DELETE FROM users WHERE id = ?`},
+		{&r.list, `SELECT id, name, email FROM users ORDER BY id`},
+	}
+	for _, s := range stmts {
+		stmt, err := db.PrepareContext(ctx, s.query)
+		if err != nil {
+			r.closeStmts()
+			return nil, fmt.Errorf("prepare %q: %w", s.query, err)
+		}
+		*s.stmt = stmt
+	}
+	return r, nil
+}
+
+// Close releases the prepared statements, and the database if the
+// repository was created by Open.
+func (r *UserRepository) Close() error {
+	r.closeStmts()
+	if r.closeDB {
+		return r.db.Close()
+	}
+	return nil
+}
+
+func (r *UserRepository) closeStmts() {
+	for _, stmt := range []*sql.Stmt{r.get, r.insert, r.update, r.delete, r.list} {
+		if stmt != nil {
+			_ = stmt.Close()
+		}
+	}
+}
+
+// GetUser returns the user with the given ID, or an error wrapping
+// domain.ErrNotFound.
+func (r *UserRepository) GetUser(id int) (*domain.User, error) {
+	var user domain.User
+	err := r.get.QueryRow(id).Scan(&user.ID, &user.Name, &user.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFound(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// SaveUser validates and stores user, with the same ID rules as
+// domain.MemoryUserRepository.SaveUser. An email that belongs to another
+// user is reported as a *domain.FieldError wrapping
+// domain.ErrDuplicateEmail.
+func (r *UserRepository) SaveUser(user *domain.User) error {
+	if err := user.Validate(); err != nil {
+		return err
+	}
+
+	if user.ID == 0 {
+		res, err := r.insert.Exec(user.Name, user.Email)
+		if err != nil {
+			return mapError(err)
+		}
+		id, err := res.LastInsertId()
+		if err != nil {
+			return err
+		}
+		user.ID = int(id)
+		return nil
+	}
+
+	res, err := r.update.Exec(user.Name, user.Email, user.ID)
+	if err != nil {
+		return mapError(err)
+	}
+	return expectRow(res, user.ID)
+}
+
+// DeleteUser removes the user with the given ID, or returns an error
+// wrapping domain.ErrNotFound. Deleted IDs are never reused.
+func (r *UserRepository) DeleteUser(id int) error {
+	res, err := r.delete.Exec(id)
+	if err != nil {
+		return err
+	}
+	return expectRow(res, id)
+}
+
+// ListUsers returns all users, ordered by ID.
+func (r *UserRepository) ListUsers() ([]*domain.User, error) {
+	rows, err := r.list.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := []*domain.User{}
+	for rows.Next() {
+		var user domain.User
+		if err := rows.Scan(&user.ID, &user.Name, &user.Email); err != nil {
+			return nil, err
+		}
+		users = append(users, &user)
+	}
+	return users, rows.Err()
+}
+
+// expectRow reports domain.ErrNotFound if res changed no rows.
+func expectRow(res sql.Result, id int) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound(id)
+	}
+	return nil
+}
+
+// mapError translates constraint violations into domain errors. Email is
+// the only column with a unique constraint.
+func mapError(err error) error {
+	var se *sqlite.Error
+	if errors.As(err, &se) && se.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return &domain.FieldError{Field: "email", Err: domain.ErrDuplicateEmail}
+	}
+	return err
+}
+
+func notFound(id int) error {
+	return fmt.Errorf("user %d: %w", id, domain.ErrNotFound)
+}
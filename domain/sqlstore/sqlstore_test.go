@@ -0,0 +1,140 @@
+package sqlstore_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
+	"github.com/lirany1/go-testing-framework-examples/domain/domaintest"
+	"github.com/lirany1/go-testing-framework-examples/domain/sqlstore"
+)
+
+// TestUserRepository runs the shared repository conformance suite against
+// an in-memory SQLite database.
+func TestUserRepository(t *testing.T) {
+	domaintest.TestUserRepository(t, func(t *testing.T) domaintest.UserRepository {
+		return open(t, ":memory:")
+	})
+}
+
+// TestUserRepository_Reopen demonstrates that data and the ID sequence
+// live in the database file.
+func TestUserRepository_Reopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users.db")
+	repo := open(t, path)
+	alice := &domain.User{Name: "Alice", Email: "alice@example.com"}
+	bob := &domain.User{Name: "Bob", Email: "bob@example.com"}
+	for _, user := range []*domain.User{alice, bob} {
+		if err := repo.SaveUser(user); err != nil {
+			t.Fatalf("SaveUser() error = %v", err)
+		}
+	}
+	if err := repo.DeleteUser(bob.ID); err != nil {
+		t.Fatalf("DeleteUser() error = %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	repo = open(t, path)
+	users, err := repo.ListUsers()
+	if err != nil || len(users) != 1 || *users[0] != *alice {
+		t.Fatalf("ListUsers() after reopen = %v, %v; want only %+v", users, err, *alice)
+	}
+	carol := &domain.User{Name: "Carol", Email: "carol@example.com"}
+	if err := repo.SaveUser(carol); err != nil {
+		t.Fatalf("SaveUser() error = %v", err)
+	}
+	if carol.ID <= bob.ID {
+		t.Errorf("new user got ID %d after reopen; want more than %d", carol.ID, bob.ID)
+	}
+}
+
+// TestMigrate demonstrates versioned migrations: a new database is brought
+// to the latest version, and migrating again is a no-op.
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+
+	if v, err := sqlstore.SchemaVersion(ctx, db); err != nil || v != 0 {
+		t.Fatalf("SchemaVersion() on a new database = %d, %v; want 0, nil", v, err)
+	}
+	applied, err := sqlstore.Migrate(ctx, db)
+	if err != nil || applied != sqlstore.LatestVersion() {
+		t.Fatalf("Migrate() = %d, %v; want %d, nil", applied, err, sqlstore.LatestVersion())
+	}
+	if v, err := sqlstore.SchemaVersion(ctx, db); err != nil || v != sqlstore.LatestVersion() {
+		t.Errorf("SchemaVersion() after Migrate = %d, %v; want %d, nil", v, err, sqlstore.LatestVersion())
+	}
+	if applied, err := sqlstore.Migrate(ctx, db); err != nil || applied != 0 {
+		t.Errorf("second Migrate() = %d, %v; want 0, nil", applied, err)
+	}
+
+	// The unique index from the second migration is in place.
+	insert := `INSERT INTO users (name, email) VALUES ('A', 'a@example.com')`
+	if _, err := db.Exec(insert); err != nil {
+		t.Fatalf("first insert error = %v", err)
+	}
+	if _, err := db.Exec(insert); err == nil {
+		t.Error("second insert with the same email succeeded")
+	}
+}
+
+// TestMigrate_SchemaTooNew checks that old code refuses a newer database.
+func TestMigrate_SchemaTooNew(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	if _, err := sqlstore.Migrate(ctx, db); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	_, err := db.Exec(`INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, 'future', '')`,
+		sqlstore.LatestVersion()+1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = sqlstore.New(db)
+	var tooNew *sqlstore.SchemaTooNewError
+	if !errors.As(err, &tooNew) || tooNew.Have != sqlstore.LatestVersion()+1 {
+		t.Errorf("New() error = %v; want *SchemaTooNewError", err)
+	}
+}
+
+// TestNew_CallerOwnsDB checks that Close leaves a caller's database open.
+func TestNew_CallerOwnsDB(t *testing.T) {
+	db := openDB(t)
+	repo, err := sqlstore.New(db)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if err := repo.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Errorf("Ping() after Close = %v; want the database still open", err)
+	}
+}
+
+func open(t *testing.T, path string) *sqlstore.UserRepository {
+	t.Helper()
+	repo, err := sqlstore.Open(path)
+	if err != nil {
+		t.Fatalf("Open(%q) error = %v", path, err)
+	}
+	t.Cleanup(func() { _ = repo.Close() })
+	return repo
+}
+
+// openDB opens a new SQLite database file that the test owns.
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
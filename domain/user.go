@@ -0,0 +1,62 @@
+// Package domain holds the canonical user model shared by the testify,
+// gomock and httpexpect examples, along with its validation rules and real
+// repository implementations to test against.
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Name length limits, counted in characters after trimming spaces.
+const (
+	MinNameLength = 1
+	MaxNameLength = 100
+)
+
+// User represents a user in the system.
+type User struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// NewUser returns a validated User with a zero ID; the repository assigns
+// IDs. The name is trimmed and the email normalised with NormalizeEmail.
+// If any field is invalid, including by one of the extra rules, it returns
+// a *ValidationError listing every failing field.
+func NewUser(name, email string, rules ...Rule) (*User, error) {
+	user := &User{
+		Name:  strings.TrimSpace(name),
+		Email: NormalizeEmail(email),
+	}
+	if err := user.Validate(rules...); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// Validate checks the name and email of u and then each of the extra
+// rules, returning a *ValidationError listing every failing field, or nil.
+func (u *User) Validate(rules ...Rule) error {
+	var v ValidationError
+	v.check("name", validateName(u.Name))
+	v.check("email", ValidateEmail(u.Email))
+	for _, rule := range rules {
+		if fe := rule(u); fe != nil {
+			v.Fields = append(v.Fields, fe)
+		}
+	}
+	return v.err()
+}
+
+func validateName(name string) error {
+	name = strings.TrimSpace(name)
+	switch n := utf8.RuneCountInString(name); {
+	case n < MinNameLength:
+		return ErrRequired
+	case n > MaxNameLength:
+		return ErrTooLong
+	}
+	return nil
+}
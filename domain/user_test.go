@@ -0,0 +1,163 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lirany1/go-testing-framework-examples/domain"
+)
+
+// TestNewUser demonstrates constructor-level validation.
+func TestNewUser(t *testing.T) {
+	user, err := domain.NewUser("  Jane Doe ", " jane@example.com")
+	if err != nil {
+		t.Fatalf("NewUser() error = %v", err)
+	}
+	want := domain.User{Name: "Jane Doe", Email: "jane@example.com"}
+	if *user != want {
+		t.Errorf("NewUser() = %+v; want %+v", *user, want)
+	}
+}
+
+// TestNewUser_ValidationErrors checks that every failing field is listed.
+func TestNewUser_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		user   string
+		email  string
+		fields map[string]error
+	}{
+		{"empty name", "", "a@example.com", map[string]error{"name": domain.ErrRequired}},
+		{"blank name", "   ", "a@example.com", map[string]error{"name": domain.ErrRequired}},
+		{"long name", strings.Repeat("é", domain.MaxNameLength+1), "a@example.com", map[string]error{"name": domain.ErrTooLong}},
+		{"empty email", "Ann", "", map[string]error{"email": domain.ErrRequired}},
+		{"invalid email", "Ann", "ann.example.com", map[string]error{"email": domain.ErrInvalidEmail}},
+		{"both invalid", "", "@", map[string]error{"name": domain.ErrRequired, "email": domain.ErrInvalidEmail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user, err := domain.NewUser(tt.user, tt.email)
+			if user != nil {
+				t.Errorf("NewUser() returned a user with an error")
+			}
+			var verr *domain.ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("NewUser() error = %v; want *ValidationError", err)
+			}
+			if len(verr.Fields) != len(tt.fields) {
+				t.Errorf("failing fields = %v; want %d", verr.Fields, len(tt.fields))
+			}
+			for field, reason := range tt.fields {
+				fe := verr.Field(field)
+				if fe == nil || !errors.Is(fe, reason) {
+					t.Errorf("field %s error = %v; want %v", field, fe, reason)
+				}
+				if !errors.Is(err, reason) {
+					t.Errorf("errors.Is(err, %v) = false", reason)
+				}
+			}
+		})
+	}
+
+	_, err := domain.NewUser("", "nope")
+	if err.Error() != "invalid user: name: is required; email: is not a valid email address" {
+		t.Errorf("message = %q", err.Error())
+	}
+}
+
+// TestValidateEmail demonstrates the accepted RFC 5322 subset.
+func TestValidateEmail(t *testing.T) {
+	valid := []string{
+		"john@example.com",
+		"jane.doe+tag@example.co.uk",
+		"o'brien@mail-server.example",
+		"x@a.io",
+		"UPPER@EXAMPLE.COM",
+		"!#$%&'*+/=?^_`{|}~-@example.com",
+		strings.Repeat("a", 64) + "@example.com",
+	}
+	for _, email := range valid {
+		if err := domain.ValidateEmail(email); err != nil {
+			t.Errorf("ValidateEmail(%q) = %v; want nil", email, err)
+		}
+	}
+
+	invalid := map[string]error{
+		"":                                       domain.ErrRequired,
+		strings.Repeat("a", 250) + "@b.com":      domain.ErrTooLong,
+		"plain":                                  domain.ErrInvalidEmail,
+		"@example.com":                           domain.ErrInvalidEmail,
+		"john@":                                  domain.ErrInvalidEmail,
+		"john@localhost":                         domain.ErrInvalidEmail,
+		"john..doe@example.com":                  domain.ErrInvalidEmail,
+		".john@example.com":                      domain.ErrInvalidEmail,
+		"john.@example.com":                      domain.ErrInvalidEmail,
+		"john doe@example.com":                   domain.ErrInvalidEmail,
+		"john@exa_mple.com":                      domain.ErrInvalidEmail,
+		"john@-example.com":                      domain.ErrInvalidEmail,
+		"john@example..com":                      domain.ErrInvalidEmail,
+		"john@a@example.com":                     domain.ErrInvalidEmail,
+		`"john doe"@example.com`:                 domain.ErrInvalidEmail,
+		"John <john@example.com>":                domain.ErrInvalidEmail,
+		strings.Repeat("a", 65) + "@example.com": domain.ErrInvalidEmail,
+	}
+	for email, want := range invalid {
+		if err := domain.ValidateEmail(email); !errors.Is(err, want) {
+			t.Errorf("ValidateEmail(%q) = %v; want %v", email, err, want)
+		}
+	}
+}
+
+// TestNormalizeEmail demonstrates that only the domain is case-folded.
+func TestNormalizeEmail(t *testing.T) {
+	tests := map[string]string{
+		" John.Doe@Example.COM ": "John.Doe@example.com",
+		"jane@example.com":       "jane@example.com",
+		"no-at-sign ":            "no-at-sign",
+		"":                       "",
+	}
+	for in, want := range tests {
+		if got := domain.NormalizeEmail(in); got != want {
+			t.Errorf("NormalizeEmail(%q) = %q; want %q", in, got, want)
+		}
+	}
+}
+
+// TestBlockedDomains demonstrates an extra Rule passed to NewUser.
+func TestBlockedDomains(t *testing.T) {
+	blocked := domain.BlockedDomains("Mailinator.com", " spam.test ")
+
+	for _, email := range []string{"a@mailinator.com", "a@MAILINATOR.COM", "a@eu.mailinator.com", "a@spam.test"} {
+		_, err := domain.NewUser("Ann", email, blocked)
+		var ve *domain.ValidationError
+		if !errors.As(err, &ve) || !errors.Is(ve.Field("email"), domain.ErrBlockedDomain) {
+			t.Errorf("NewUser(%q) error = %v; want email %v", email, err, domain.ErrBlockedDomain)
+		}
+	}
+	for _, email := range []string{"a@example.com", "a@notmailinator.com", "a@mailinator.com.example"} {
+		if _, err := domain.NewUser("Ann", email, blocked); err != nil {
+			t.Errorf("NewUser(%q) error = %v; want nil", email, err)
+		}
+	}
+
+	// An address without a domain is only reported once, by ValidateEmail.
+	_, err := domain.NewUser("Ann", "ann", blocked)
+	var ve *domain.ValidationError
+	if !errors.As(err, &ve) || len(ve.Fields) != 1 || !errors.Is(ve.Fields[0], domain.ErrInvalidEmail) {
+		t.Errorf("NewUser(%q) error = %v; want a single invalid email error", "ann", err)
+	}
+}
+
+// TestUser_JSON checks the wire format the HTTP examples rely on.
+func TestUser_JSON(t *testing.T) {
+	data, err := json.Marshal(domain.User{ID: 1, Name: "John Doe", Email: "john@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"id":1,"name":"John Doe","email":"john@example.com"}` {
+		t.Errorf("json.Marshal(User) = %s", data)
+	}
+}